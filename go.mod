@@ -3,11 +3,12 @@ module github.com/kaiachain/kaia-load-tester
 go 1.23.7
 
 require (
+	github.com/holiman/uint256 v1.3.2
 	github.com/kaiachain/kaia v1.0.4-0.20251002025735-0bc8cf5337d0 // v2.0.0 commit hash
 	github.com/myzhan/boomer v1.6.0
 	github.com/tidwall/gjson v1.12.1
 	github.com/urfave/cli v1.20.0
-	golang.org/x/crypto v0.36.0 // indirect
+	golang.org/x/crypto v0.36.0
 )
 
 require (
@@ -64,7 +65,6 @@ require (
 	github.com/google/uuid v1.6.0 // indirect
 	github.com/gorilla/websocket v1.5.0 // indirect
 	github.com/hashicorp/golang-lru v1.0.2 // indirect
-	github.com/holiman/uint256 v1.3.2 // indirect
 	github.com/huin/goupnp v1.3.0 // indirect
 	github.com/jackpal/go-nat-pmp v1.0.2 // indirect
 	github.com/jmespath/go-jmespath v0.4.0 // indirect
@@ -19,6 +19,9 @@ const (
 	AccListForNewAccounts
 	AccListForGaslessRevertTx
 	AccListForGaslessApproveTx
+	AccListForPublicKeyTx
+	AccListForMultiSigTx
+	AccListForRoleBasedTx
 	AccListEnd
 )
 
@@ -45,6 +48,11 @@ const (
 	ContractUserStorage
 	ContractInternalTxKIP17
 	ContractInternalTxMain
+	ContractLogHeavy
+	ContractEIP7702Delegate
+	ContractCreate2Factory
+	ContractStateGrowth
+	ContractComputationLoop
 	ContractEnd
 )
 
@@ -55,6 +63,10 @@ type AccGroup struct {
 
 	accLists  [][]*Account
 	contracts []*Account
+
+	// If hdWallet is set, test accounts are derived from it instead of being randomly generated.
+	hdWallet   *HDWallet
+	slaveIndex int
 }
 
 func NewAccGroup(chainId *big.Int, gasPrice *big.Int, baseFee *big.Int, contains bool) *AccGroup {
@@ -70,6 +82,12 @@ func NewAccGroup(chainId *big.Int, gasPrice *big.Int, baseFee *big.Int, contains
 }
 func (a *AccGroup) Load(loader AccLoader) { loader(a) }
 
+// SetHDWallet makes CreateAccountsPerAccGrp derive the test accounts of the given slave from the wallet.
+func (a *AccGroup) SetHDWallet(w *HDWallet, slaveIndex int) {
+	a.hdWallet = w
+	a.slaveIndex = slaveIndex
+}
+
 func (a *AccGroup) GetTestContractList() []*Account               { return a.contracts }
 func (a *AccGroup) GetTestContractByName(t TestContract) *Account { return a.contracts[t] }
 func (a *AccGroup) GetAccListByName(t AccList) []*Account         { return a.accLists[t] }
@@ -83,11 +101,16 @@ func (a *AccGroup) SetAccListByName(accs []*Account, t AccList) {
 func (a *AccGroup) AddAccToListByName(acc *Account, t AccList) {
 	a.accLists[t] = append(a.accLists[t], acc)
 }
-func (a *AccGroup) CreateAccountsPerAccGrp(nUserForSignedTx int, nUserForUnsignedTx int, nUserForNewAccounts int, nUserForGaslessRevertTx int, nUserForGaslessApproveTx int, tcStrList []string, gEndpoint string) {
-	for idx, nUser := range []int{nUserForSignedTx, nUserForUnsignedTx, nUserForNewAccounts, nUserForGaslessRevertTx, nUserForGaslessApproveTx} {
+func (a *AccGroup) CreateAccountsPerAccGrp(nUserForSignedTx int, nUserForUnsignedTx int, nUserForNewAccounts int, nUserForGaslessRevertTx int, nUserForGaslessApproveTx int, nUserForPublicKeyTx int, nUserForMultiSigTx int, nUserForRoleBasedTx int, tcStrList []string, gEndpoint string) {
+	for idx, nUser := range []int{nUserForSignedTx, nUserForUnsignedTx, nUserForNewAccounts, nUserForGaslessRevertTx, nUserForGaslessApproveTx, nUserForPublicKeyTx, nUserForMultiSigTx, nUserForRoleBasedTx} {
 		println(idx, " Account Group Preparation...")
 		for i := 0; i < nUser; i++ {
-			account := NewAccount(i)
+			var account *Account
+			if a.hdWallet != nil {
+				account = NewHDAccount(a.hdWallet, a.slaveIndex, AccList(idx), i)
+			} else {
+				account = NewAccount(i)
+			}
 			a.AddAccToListByName(account, AccList(idx))
 			fmt.Printf("%v\n", account.address.String())
 		}
@@ -133,6 +156,22 @@ func (a *AccGroup) GetValidAccGrp() []*Account {
 	return accGrp
 }
 
+var (
+	// erc20ChargeAmount is the amount of erc20 tokens charged to every test account.
+	erc20ChargeAmount = big.NewInt(1e4)
+	// erc721MintPerAccount is the number of erc721 tokens minted to every test account.
+	erc721MintPerAccount = 5
+)
+
+// SetTestTokenConfig sets the erc20 tokens charged and the number of erc721 tokens minted to every test account.
+func SetTestTokenConfig(erc20Amount *big.Int, erc721Tokens int) {
+	if erc20Amount.Sign() < 0 || erc721Tokens <= 0 {
+		log.Fatalf("the erc20 charge amount should not be negative, and the erc721 tokens per account should be positive: %v, %v", erc20Amount, erc721Tokens)
+	}
+	erc20ChargeAmount = erc20Amount
+	erc721MintPerAccount = erc721Tokens
+}
+
 func (a *AccGroup) DeployTestContracts(tcList []string, targetTxTypeList []string, localReservoir *Account, gCli *client.Client, chargeValue *big.Int, maxConcurrency int) {
 	inTheTcList := func(testNames []string) bool {
 		for _, tcName := range tcList {
@@ -175,13 +214,17 @@ func (a *AccGroup) DeployTestContracts(tcList []string, targetTxTypeList []strin
 		// additional work - erc20 token charging or erc721 minting
 		if TestContract(idx) == ContractErc20 {
 			log.Printf("Start erc20 token charging to the test account group")
-			TestContractInfos[ContractErc20].deployer.SmartContractExecutionWithGuaranteeRetry(gCli, a.contracts[ContractErc20], nil, TestContractInfos[ContractErc20].GenData(localReservoir.address, big.NewInt(1e11)))
+			reservoirAmount := new(big.Int).Mul(erc20ChargeAmount, big.NewInt(int64(len(a.GetValidAccGrp()))))
+			if reservoirAmount.Cmp(big.NewInt(1e11)) < 0 {
+				reservoirAmount = big.NewInt(1e11)
+			}
+			TestContractInfos[ContractErc20].deployer.SmartContractExecutionWithGuaranteeRetry(gCli, a.contracts[ContractErc20], nil, TestContractInfos[ContractErc20].GenData(localReservoir.address, reservoirAmount))
 			ConcurrentTransactionSend(a.GetValidAccGrp(), maxConcurrency, func(acc *Account) {
-				localReservoir.SmartContractExecutionWithGuaranteeRetry(gCli, a.contracts[ContractErc20], nil, TestContractInfos[ContractErc20].GenData(acc.address, big.NewInt(1e4)))
+				localReservoir.SmartContractExecutionWithGuaranteeRetry(gCli, a.contracts[ContractErc20], nil, TestContractInfos[ContractErc20].GenData(acc.address, erc20ChargeAmount))
 			})
 		} else if TestContract(idx) == ContractErc721 {
 			log.Printf("Start erc721 nft minting to the test account group(similar to erc20 token charging)")
-			localReservoir.MintERC721ToTestAccounts(gCli, a.GetValidAccGrp(), a.GetTestContractByName(ContractErc721).GetAddress(), 5)
+			localReservoir.MintERC721ToTestAccounts(gCli, a.GetValidAccGrp(), a.GetTestContractByName(ContractErc721).GetAddress(), erc721MintPerAccount)
 		} else if TestContract(idx) == ContractGaslessToken && (inTheTcList([]string{"gaslessTransactionTC", "gaslessOnlyApproveTC"}) || inTheTargetTxTypeList([]string{"GAA", "GAS"})) {
 			log.Printf("Start gasless test token charging to the test account group")
 			lenValidAccGrp := big.NewInt(int64(len(a.GetValidAccGrp())))
@@ -204,6 +247,7 @@ type AccountSet struct {
 	accounts        []*Account
 	mu              sync.Mutex
 	roundRobinIndex int
+	ringIndex       int // the next account replaced by AddRing once the set is full
 }
 
 func NewAccountSet(accounts []*Account) *AccountSet {
@@ -218,12 +262,31 @@ func (a *AccountSet) Len() int {
 	return len(a.accounts)
 }
 
+// LockedLen returns the number of the accounts under the lock, so that it is safe while the accounts are added concurrently.
+func (a *AccountSet) LockedLen() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.Len()
+}
+
 func (a *AccountSet) Add(acc *Account) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 	a.accounts = append(a.accounts, acc)
 }
 
+// AddRing adds the account like a ring buffer of the given capacity. Once the set is full, the oldest added account is replaced.
+func (a *AccountSet) AddRing(acc *Account, capacity int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.Len() < capacity {
+		a.accounts = append(a.accounts, acc)
+		return
+	}
+	a.accounts[a.ringIndex] = acc
+	a.ringIndex = (a.ringIndex + 1) % a.Len()
+}
+
 func (a *AccountSet) GetAccountRandomly() *Account {
 	a.mu.Lock()
 	defer a.mu.Unlock()
@@ -246,3 +309,18 @@ func (a *AccountSet) GetAccountRoundRobin() *Account {
 	}
 	return acc
 }
+
+// GetAccountsRoundRobin returns the next n accounts in round robin, which are distinct if n is not larger than the set.
+func (a *AccountSet) GetAccountsRoundRobin(n int) []*Account {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	accs := make([]*Account, n)
+	for i := range accs {
+		accs[i] = a.accounts[a.roundRobinIndex]
+		a.roundRobinIndex++
+		if a.roundRobinIndex >= a.Len() {
+			a.roundRobinIndex = 0
+		}
+	}
+	return accs
+}
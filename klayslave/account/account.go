@@ -16,6 +16,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/holiman/uint256"
 	kaia "github.com/kaiachain/kaia"
 	"github.com/kaiachain/kaia/accounts/abi"
 	"github.com/kaiachain/kaia/accounts/abi/bind"
@@ -39,6 +40,9 @@ var (
 	gasPrice *big.Int
 	chainID  *big.Int
 	baseFee  *big.Int
+
+	// retryBackoff is called before the *WithGuaranteeRetry helpers retry a failed tx.
+	retryBackoff = func() { time.Sleep(1 * time.Second) }
 )
 
 type Account struct {
@@ -74,6 +78,11 @@ func SetBaseFee(bf *big.Int) {
 	baseFee = bf
 }
 
+// SetRetryBackoff replaces the wait before the *WithGuaranteeRetry helpers retry a failed tx, e.g. to wait until the txpool has room.
+func SetRetryBackoff(backoff func()) {
+	retryBackoff = backoff
+}
+
 func SetChainID(id *big.Int) {
 	chainID = id
 }
@@ -110,7 +119,7 @@ func (account *Account) ImportUnLockAccount(endpoint string) {
 	key := account.key[0]
 	acc, err := crypto.HexToECDSA(key)
 	if err != nil {
-		log.Fatalf("Key(%v): Failed to HexToECDSA %v", err)
+		log.Fatalf("Key(%v): Failed to HexToECDSA %v", key, err)
 	}
 
 	testAddr := crypto.PubkeyToAddress(acc.PublicKey)
@@ -361,7 +370,7 @@ func (self *Account) TransferSignedTxWithGuaranteeRetry(c *client.Client, to *Ac
 			break // Succeed, let's break the loop
 		}
 		log.Printf("Failed to execute: err=%s", err.Error())
-		time.Sleep(1 * time.Second) // Mostly, the err is `txpool is full`, retry after a while.
+		retryBackoff() // Mostly, the err is `txpool is full`, retry after a while.
 		//numChargedAcc, lastFailedNum = estimateRemainingTime(accGrp, numChargedAcc, lastFailedNum)
 	}
 
@@ -441,7 +450,7 @@ func (self *Account) TransferNewValueTransferWithCancelTx(c *client.Client, to *
 		types.TxValueKeyNonce:    nonce,
 		types.TxValueKeyTo:       to.GetAddress(),
 		types.TxValueKeyAmount:   value,
-		types.TxValueKeyGasLimit: uint64(100000),
+		types.TxValueKeyGasLimit: gasLimitOf(c, uint64(100000)),
 		types.TxValueKeyGasPrice: gasPrice,
 		types.TxValueKeyFrom:     self.address,
 	})
@@ -459,7 +468,7 @@ func (self *Account) TransferNewValueTransferWithCancelTx(c *client.Client, to *
 	cancelTx, err := types.NewTransactionWithMap(types.TxTypeCancel, map[types.TxValueKeyType]interface{}{
 		types.TxValueKeyNonce:    nonce,
 		types.TxValueKeyFrom:     self.address,
-		types.TxValueKeyGasLimit: uint64(100000000),
+		types.TxValueKeyGasLimit: gasLimitOf(c, uint64(100000000)),
 		types.TxValueKeyGasPrice: gasPrice,
 	})
 	if err != nil {
@@ -473,9 +482,10 @@ func (self *Account) TransferNewValueTransferWithCancelTx(c *client.Client, to *
 
 	txList = append(txList, cancelTx)
 
+	// The hash of the last tx sent, i.e. of the cancel tx, is returned
 	var hash common.Hash
 	for _, tx := range txList {
-		hash, err := c.SendRawTransaction(ctx, tx)
+		hash, err = c.SendRawTransaction(ctx, tx)
 		if err != nil {
 			if err.Error() == blockchain.ErrNonceTooLow.Error() || err.Error() == blockchain.ErrReplaceUnderpriced.Error() {
 				fmt.Printf("Account(%v) nonce(%v) : Failed to sendTransaction: %v\n", self.GetAddress().String(), nonce, err)
@@ -505,7 +515,7 @@ func (self *Account) TransferNewValueTransferTx(c *client.Client, to *Account, v
 		types.TxValueKeyNonce:    nonce,
 		types.TxValueKeyTo:       to.GetAddress(),
 		types.TxValueKeyAmount:   value,
-		types.TxValueKeyGasLimit: uint64(100000),
+		types.TxValueKeyGasLimit: gasLimitOf(c, uint64(100000)+self.sigValidationGas()),
 		types.TxValueKeyGasPrice: gasPrice,
 		types.TxValueKeyFrom:     self.address,
 	})
@@ -548,7 +558,7 @@ func (self *Account) TransferNewFeeDelegatedValueTransferTx(c *client.Client, to
 		types.TxValueKeyNonce:    nonce,
 		types.TxValueKeyTo:       to.GetAddress(),
 		types.TxValueKeyAmount:   value,
-		types.TxValueKeyGasLimit: uint64(100000),
+		types.TxValueKeyGasLimit: gasLimitOf(c, uint64(100000)),
 		types.TxValueKeyGasPrice: gasPrice,
 		types.TxValueKeyFrom:     self.address,
 		types.TxValueKeyFeePayer: to.address,
@@ -584,7 +594,7 @@ func (self *Account) TransferNewFeeDelegatedValueTransferTx(c *client.Client, to
 	return hash, gasPrice, nil
 }
 
-func (self *Account) TransferNewFeeDelegatedValueTransferWithRatioTx(c *client.Client, to *Account, value *big.Int) (common.Hash, *big.Int, error) {
+func (self *Account) TransferNewFeeDelegatedValueTransferWithRatioTx(c *client.Client, to *Account, value *big.Int, feeRatio types.FeeRatio) (common.Hash, *big.Int, error) {
 	ctx := context.Background() //context.WithTimeout(context.Background(), 100*time.Second)
 
 	self.mutex.Lock()
@@ -597,11 +607,11 @@ func (self *Account) TransferNewFeeDelegatedValueTransferWithRatioTx(c *client.C
 		types.TxValueKeyNonce:              nonce,
 		types.TxValueKeyTo:                 to.GetAddress(),
 		types.TxValueKeyAmount:             value,
-		types.TxValueKeyGasLimit:           uint64(100000),
+		types.TxValueKeyGasLimit:           gasLimitOf(c, uint64(100000)),
 		types.TxValueKeyGasPrice:           gasPrice,
 		types.TxValueKeyFrom:               self.address,
 		types.TxValueKeyFeePayer:           to.address,
-		types.TxValueKeyFeeRatioOfFeePayer: types.FeeRatio(30),
+		types.TxValueKeyFeeRatioOfFeePayer: feeRatio,
 	})
 	if err != nil {
 		log.Fatalf("Failed to encode tx: %v", err)
@@ -648,7 +658,7 @@ func (self *Account) createMemoTransferTx(c *client.Client, to *Account, value *
 		types.TxValueKeyNonce:    nonce,
 		types.TxValueKeyTo:       to.GetAddress(),
 		types.TxValueKeyAmount:   value,
-		types.TxValueKeyGasLimit: gasLimit,
+		types.TxValueKeyGasLimit: gasLimitOf(c, gasLimit),
 		types.TxValueKeyGasPrice: gasPrice,
 		types.TxValueKeyData:     data,
 		types.TxValueKeyFrom:     self.address,
@@ -725,7 +735,7 @@ func (self *Account) TransferNewFeeDelegatedValueTransferMemoTx(c *client.Client
 		types.TxValueKeyNonce:    nonce,
 		types.TxValueKeyTo:       to.GetAddress(),
 		types.TxValueKeyAmount:   value,
-		types.TxValueKeyGasLimit: uint64(100000),
+		types.TxValueKeyGasLimit: gasLimitOf(c, uint64(100000)),
 		types.TxValueKeyGasPrice: gasPrice,
 		types.TxValueKeyData:     data,
 		types.TxValueKeyFrom:     self.address,
@@ -762,7 +772,7 @@ func (self *Account) TransferNewFeeDelegatedValueTransferMemoTx(c *client.Client
 	return hash, gasPrice, nil
 }
 
-func (self *Account) TransferNewFeeDelegatedValueTransferMemoWithRatioTx(c *client.Client, to *Account, value *big.Int) (common.Hash, *big.Int, error) {
+func (self *Account) TransferNewFeeDelegatedValueTransferMemoWithRatioTx(c *client.Client, to *Account, value *big.Int, feeRatio types.FeeRatio) (common.Hash, *big.Int, error) {
 	ctx := context.Background() //context.WithTimeout(context.Background(), 100*time.Second)
 
 	self.mutex.Lock()
@@ -776,12 +786,12 @@ func (self *Account) TransferNewFeeDelegatedValueTransferMemoWithRatioTx(c *clie
 		types.TxValueKeyNonce:              nonce,
 		types.TxValueKeyTo:                 to.GetAddress(),
 		types.TxValueKeyAmount:             value,
-		types.TxValueKeyGasLimit:           uint64(100000),
+		types.TxValueKeyGasLimit:           gasLimitOf(c, uint64(100000)),
 		types.TxValueKeyGasPrice:           gasPrice,
 		types.TxValueKeyData:               data,
 		types.TxValueKeyFrom:               self.address,
 		types.TxValueKeyFeePayer:           to.address,
-		types.TxValueKeyFeeRatioOfFeePayer: types.FeeRatio(30),
+		types.TxValueKeyFeeRatioOfFeePayer: feeRatio,
 	})
 	if err != nil {
 		log.Fatalf("Failed to encode tx: %v", err)
@@ -814,6 +824,8 @@ func (self *Account) TransferNewFeeDelegatedValueTransferMemoWithRatioTx(c *clie
 	return hash, gasPrice, nil
 }
 
+// TransferNewAccountCreationTx is kept for reference only.
+// Kaia nodes no longer decode TxTypeAccountCreation, so use CreateNewAccount to onboard new accounts.
 func (self *Account) TransferNewAccountCreationTx(c *client.Client, to *Account, value *big.Int) (common.Hash, *big.Int, error) {
 	ctx := context.Background() //context.WithTimeout(context.Background(), 100*time.Second)
 
@@ -828,7 +840,7 @@ func (self *Account) TransferNewAccountCreationTx(c *client.Client, to *Account,
 		types.TxValueKeyFrom:          self.address,
 		types.TxValueKeyTo:            to.GetAddress(),
 		types.TxValueKeyAmount:        value,
-		types.TxValueKeyGasLimit:      uint64(1000000),
+		types.TxValueKeyGasLimit:      gasLimitOf(c, uint64(1000000)),
 		types.TxValueKeyGasPrice:      gasPrice,
 		types.TxValueKeyHumanReadable: false,
 		types.TxValueKeyAccountKey:    accountkey.NewAccountKeyPublicWithValue(&to.privateKey[0].PublicKey),
@@ -871,7 +883,7 @@ func (self *Account) TransferNewAccountUpdateTx(c *client.Client, to *Account, v
 	tx, err := types.NewTransactionWithMap(types.TxTypeAccountUpdate, map[types.TxValueKeyType]interface{}{
 		types.TxValueKeyNonce:      nonce,
 		types.TxValueKeyFrom:       self.address,
-		types.TxValueKeyGasLimit:   uint64(100000),
+		types.TxValueKeyGasLimit:   gasLimitOf(c, uint64(100000)),
 		types.TxValueKeyGasPrice:   gasPrice,
 		types.TxValueKeyAccountKey: accountkey.NewAccountKeyPublicWithValue(&self.privateKey[0].PublicKey),
 	})
@@ -913,7 +925,7 @@ func (self *Account) TransferNewFeeDelegatedAccountUpdateTx(c *client.Client, to
 	tx, err := types.NewTransactionWithMap(types.TxTypeFeeDelegatedAccountUpdate, map[types.TxValueKeyType]interface{}{
 		types.TxValueKeyNonce:      nonce,
 		types.TxValueKeyFrom:       self.address,
-		types.TxValueKeyGasLimit:   uint64(100000),
+		types.TxValueKeyGasLimit:   gasLimitOf(c, uint64(100000)),
 		types.TxValueKeyGasPrice:   gasPrice,
 		types.TxValueKeyAccountKey: accountkey.NewAccountKeyPublicWithValue(&self.privateKey[0].PublicKey),
 		types.TxValueKeyFeePayer:   to.address,
@@ -949,7 +961,7 @@ func (self *Account) TransferNewFeeDelegatedAccountUpdateTx(c *client.Client, to
 	return hash, gasPrice, nil
 }
 
-func (self *Account) TransferNewFeeDelegatedAccountUpdateWithRatioTx(c *client.Client, to *Account, value *big.Int) (common.Hash, *big.Int, error) {
+func (self *Account) TransferNewFeeDelegatedAccountUpdateWithRatioTx(c *client.Client, to *Account, value *big.Int, feeRatio types.FeeRatio) (common.Hash, *big.Int, error) {
 	ctx := context.Background() //context.WithTimeout(context.Background(), 100*time.Second)
 
 	self.mutex.Lock()
@@ -961,11 +973,11 @@ func (self *Account) TransferNewFeeDelegatedAccountUpdateWithRatioTx(c *client.C
 	tx, err := types.NewTransactionWithMap(types.TxTypeFeeDelegatedAccountUpdateWithRatio, map[types.TxValueKeyType]interface{}{
 		types.TxValueKeyNonce:              nonce,
 		types.TxValueKeyFrom:               self.address,
-		types.TxValueKeyGasLimit:           uint64(100000),
+		types.TxValueKeyGasLimit:           gasLimitOf(c, uint64(100000)),
 		types.TxValueKeyGasPrice:           gasPrice,
 		types.TxValueKeyAccountKey:         accountkey.NewAccountKeyPublicWithValue(&self.privateKey[0].PublicKey),
 		types.TxValueKeyFeePayer:           to.address,
-		types.TxValueKeyFeeRatioOfFeePayer: types.FeeRatio(30),
+		types.TxValueKeyFeeRatioOfFeePayer: feeRatio,
 	})
 	if err != nil {
 		log.Fatalf("Failed to encode tx: %v", err)
@@ -1015,7 +1027,7 @@ func (self *Account) TransferNewSmartContractDeployTx(c *client.Client, to *Acco
 		types.TxValueKeyFrom:          self.address,
 		types.TxValueKeyTo:            (*common.Address)(nil),
 		types.TxValueKeyAmount:        value,
-		types.TxValueKeyGasLimit:      uint64(10000000),
+		types.TxValueKeyGasLimit:      gasLimitOf(c, uint64(10000000)),
 		types.TxValueKeyGasPrice:      gasPrice,
 		types.TxValueKeyHumanReadable: false,
 		types.TxValueKeyCodeFormat:    params.CodeFormatEVM,
@@ -1051,6 +1063,51 @@ func (self *Account) TransferNewSmartContractDeployTx(c *client.Client, to *Acco
 	return contractAddr, tx, gasPrice, nil
 }
 
+// TransferNewCreate2DeployTx deploys the initcode by the CREATE2 factory with the salt, and returns the address of the new contract.
+func (self *Account) TransferNewCreate2DeployTx(c *client.Client, factory *Account, salt common.Hash, initCode []byte) (common.Address, *types.Transaction, *big.Int, error) {
+	ctx := context.Background() //context.WithTimeout(context.Background(), 100*time.Second)
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	nonce := self.GetNonce(c)
+
+	signer := types.NewEIP155Signer(chainID)
+	tx, err := types.NewTransactionWithMap(types.TxTypeSmartContractExecution, map[types.TxValueKeyType]interface{}{
+		types.TxValueKeyNonce:    nonce,
+		types.TxValueKeyGasPrice: gasPrice,
+		types.TxValueKeyGasLimit: gasLimitOf(c, uint64(10000000)),
+		types.TxValueKeyFrom:     self.address,
+		types.TxValueKeyTo:       factory.address,
+		types.TxValueKeyAmount:   big.NewInt(0),
+		types.TxValueKeyData:     append(salt.Bytes(), initCode...),
+	})
+	if err != nil {
+		log.Fatalf("Failed to encode tx: %v", err)
+	}
+
+	err = tx.SignWithKeys(signer, self.privateKey)
+	if err != nil {
+		log.Fatalf("Failed to sign tx: %v", err)
+	}
+
+	_, err = c.SendRawTransaction(ctx, tx)
+	if err != nil {
+		if err.Error() == blockchain.ErrNonceTooLow.Error() || err.Error() == blockchain.ErrReplaceUnderpriced.Error() {
+			fmt.Printf("Account(%v) nonce(%v) : Failed to sendTransaction: %v\n", self.GetAddress().String(), nonce, err)
+			fmt.Printf("Account(%v) nonce is added to %v\n", self.GetAddress().String(), nonce+1)
+			self.nonce++
+		} else {
+			fmt.Printf("Account(%v) nonce(%v) : Failed to sendTransaction: %v\n", self.GetAddress().String(), nonce, err)
+		}
+		return common.Address{}, tx, gasPrice, err
+	}
+
+	self.nonce++
+
+	return crypto.CreateAddress2(factory.address, salt, crypto.Keccak256(initCode)), tx, gasPrice, nil
+}
+
 func (self *Account) TransferNewFeeDelegatedSmartContractDeployTx(c *client.Client, to *Account, value *big.Int) (common.Hash, *big.Int, error) {
 	ctx := context.Background() //context.WithTimeout(context.Background(), 100*time.Second)
 
@@ -1067,7 +1124,7 @@ func (self *Account) TransferNewFeeDelegatedSmartContractDeployTx(c *client.Clie
 		types.TxValueKeyFrom:          self.address,
 		types.TxValueKeyTo:            (*common.Address)(nil),
 		types.TxValueKeyAmount:        common.Big0,
-		types.TxValueKeyGasLimit:      uint64(10000000),
+		types.TxValueKeyGasLimit:      gasLimitOf(c, uint64(10000000)),
 		types.TxValueKeyGasPrice:      gasPrice,
 		types.TxValueKeyHumanReadable: false,
 		types.TxValueKeyData:          common.FromHex(code),
@@ -1105,7 +1162,7 @@ func (self *Account) TransferNewFeeDelegatedSmartContractDeployTx(c *client.Clie
 	return hash, gasPrice, nil
 }
 
-func (self *Account) TransferNewFeeDelegatedSmartContractDeployWithRatioTx(c *client.Client, to *Account, value *big.Int) (common.Hash, *big.Int, error) {
+func (self *Account) TransferNewFeeDelegatedSmartContractDeployWithRatioTx(c *client.Client, to *Account, value *big.Int, feeRatio types.FeeRatio) (common.Hash, *big.Int, error) {
 	ctx := context.Background() //context.WithTimeout(context.Background(), 100*time.Second)
 
 	self.mutex.Lock()
@@ -1121,13 +1178,13 @@ func (self *Account) TransferNewFeeDelegatedSmartContractDeployWithRatioTx(c *cl
 		types.TxValueKeyFrom:               self.address,
 		types.TxValueKeyTo:                 (*common.Address)(nil),
 		types.TxValueKeyAmount:             common.Big0,
-		types.TxValueKeyGasLimit:           uint64(10000000),
+		types.TxValueKeyGasLimit:           gasLimitOf(c, uint64(10000000)),
 		types.TxValueKeyGasPrice:           gasPrice,
 		types.TxValueKeyHumanReadable:      false,
 		types.TxValueKeyData:               common.FromHex(code),
 		types.TxValueKeyFeePayer:           self.address,
 		types.TxValueKeyCodeFormat:         params.CodeFormatEVM,
-		types.TxValueKeyFeeRatioOfFeePayer: types.FeeRatio(30),
+		types.TxValueKeyFeeRatioOfFeePayer: feeRatio,
 	})
 	if err != nil {
 		log.Fatalf("Failed to encode tx: %v", err)
@@ -1194,7 +1251,7 @@ func (self *Account) ExecuteStorageTrieStore(c *client.Client, to *Account, valu
 	tx, err := types.NewTransactionWithMap(types.TxTypeSmartContractExecution, map[types.TxValueKeyType]interface{}{
 		types.TxValueKeyNonce:    nonce,
 		types.TxValueKeyGasPrice: gasPrice,
-		types.TxValueKeyGasLimit: uint64(5000000),
+		types.TxValueKeyGasLimit: gasLimitOf(c, uint64(5000000)),
 		types.TxValueKeyFrom:     self.address,
 		types.TxValueKeyAmount:   common.Big0,
 		types.TxValueKeyTo:       to.address,
@@ -1246,7 +1303,7 @@ func (self *Account) TransferNewSmartContractExecutionTx(c *client.Client, to *A
 	tx, err := types.NewTransactionWithMap(types.TxTypeSmartContractExecution, map[types.TxValueKeyType]interface{}{
 		types.TxValueKeyNonce:    nonce,
 		types.TxValueKeyGasPrice: gasPrice,
-		types.TxValueKeyGasLimit: uint64(5000000),
+		types.TxValueKeyGasLimit: gasLimitOf(c, uint64(5000000)),
 		types.TxValueKeyFrom:     self.address,
 		types.TxValueKeyAmount:   value,
 		types.TxValueKeyTo:       to.address,
@@ -1301,7 +1358,7 @@ func (self *Account) TransferNewFeeDelegatedSmartContractExecutionTx(c *client.C
 	tx, err := types.NewTransactionWithMap(types.TxTypeFeeDelegatedSmartContractExecution, map[types.TxValueKeyType]interface{}{
 		types.TxValueKeyNonce:    nonce,
 		types.TxValueKeyGasPrice: gasPrice,
-		types.TxValueKeyGasLimit: uint64(5000000),
+		types.TxValueKeyGasLimit: gasLimitOf(c, uint64(5000000)),
 		types.TxValueKeyFrom:     self.address,
 		types.TxValueKeyAmount:   value,
 		types.TxValueKeyTo:       to.address,
@@ -1339,7 +1396,7 @@ func (self *Account) TransferNewFeeDelegatedSmartContractExecutionTx(c *client.C
 	return hash, gasPrice, nil
 }
 
-func (self *Account) TransferNewFeeDelegatedSmartContractExecutionWithRatioTx(c *client.Client, to *Account, value *big.Int) (common.Hash, *big.Int, error) {
+func (self *Account) TransferNewFeeDelegatedSmartContractExecutionWithRatioTx(c *client.Client, to *Account, value *big.Int, feeRatio types.FeeRatio) (common.Hash, *big.Int, error) {
 	ctx := context.Background() //context.WithTimeout(context.Background(), 100*time.Second)
 
 	self.mutex.Lock()
@@ -1362,13 +1419,13 @@ func (self *Account) TransferNewFeeDelegatedSmartContractExecutionWithRatioTx(c
 	tx, err := types.NewTransactionWithMap(types.TxTypeFeeDelegatedSmartContractExecutionWithRatio, map[types.TxValueKeyType]interface{}{
 		types.TxValueKeyNonce:              nonce,
 		types.TxValueKeyGasPrice:           gasPrice,
-		types.TxValueKeyGasLimit:           uint64(5000000),
+		types.TxValueKeyGasLimit:           gasLimitOf(c, uint64(5000000)),
 		types.TxValueKeyFrom:               self.address,
 		types.TxValueKeyAmount:             value,
 		types.TxValueKeyTo:                 to.address,
 		types.TxValueKeyData:               data,
 		types.TxValueKeyFeePayer:           self.address,
-		types.TxValueKeyFeeRatioOfFeePayer: types.FeeRatio(30),
+		types.TxValueKeyFeeRatioOfFeePayer: feeRatio,
 	})
 	if err != nil {
 		log.Fatalf("Failed to encode tx: %v", err)
@@ -1413,7 +1470,7 @@ func (self *Account) TransferNewCancelTx(c *client.Client, to *Account, value *b
 	tx, err := types.NewTransactionWithMap(types.TxTypeCancel, map[types.TxValueKeyType]interface{}{
 		types.TxValueKeyNonce:    nonce,
 		types.TxValueKeyFrom:     self.address,
-		types.TxValueKeyGasLimit: uint64(100000000),
+		types.TxValueKeyGasLimit: gasLimitOf(c, uint64(100000000)),
 		types.TxValueKeyGasPrice: gasPrice,
 	})
 	if err != nil {
@@ -1454,7 +1511,7 @@ func (self *Account) TransferNewFeeDelegatedCancelTx(c *client.Client, to *Accou
 	tx, err := types.NewTransactionWithMap(types.TxTypeFeeDelegatedCancel, map[types.TxValueKeyType]interface{}{
 		types.TxValueKeyNonce:    nonce,
 		types.TxValueKeyFrom:     self.address,
-		types.TxValueKeyGasLimit: uint64(100000000),
+		types.TxValueKeyGasLimit: gasLimitOf(c, uint64(100000000)),
 		types.TxValueKeyGasPrice: gasPrice,
 		types.TxValueKeyFeePayer: to.address,
 	})
@@ -1489,7 +1546,7 @@ func (self *Account) TransferNewFeeDelegatedCancelTx(c *client.Client, to *Accou
 	return hash, gasPrice, nil
 }
 
-func (self *Account) TransferNewFeeDelegatedCancelWithRatioTx(c *client.Client, to *Account, value *big.Int) (common.Hash, *big.Int, error) {
+func (self *Account) TransferNewFeeDelegatedCancelWithRatioTx(c *client.Client, to *Account, value *big.Int, feeRatio types.FeeRatio) (common.Hash, *big.Int, error) {
 	ctx := context.Background() //context.WithTimeout(context.Background(), 100*time.Second)
 
 	self.mutex.Lock()
@@ -1501,10 +1558,155 @@ func (self *Account) TransferNewFeeDelegatedCancelWithRatioTx(c *client.Client,
 	tx, err := types.NewTransactionWithMap(types.TxTypeFeeDelegatedCancelWithRatio, map[types.TxValueKeyType]interface{}{
 		types.TxValueKeyNonce:              nonce,
 		types.TxValueKeyFrom:               self.address,
-		types.TxValueKeyGasLimit:           uint64(100000000),
+		types.TxValueKeyGasLimit:           gasLimitOf(c, uint64(100000000)),
+		types.TxValueKeyGasPrice:           gasPrice,
+		types.TxValueKeyFeePayer:           to.address,
+		types.TxValueKeyFeeRatioOfFeePayer: feeRatio,
+	})
+	if err != nil {
+		log.Fatalf("Failed to encode tx: %v", err)
+	}
+
+	err = tx.SignWithKeys(signer, self.privateKey)
+	if err != nil {
+		log.Fatalf("Failed to sign tx: %v", err)
+	}
+
+	err = tx.SignFeePayerWithKeys(signer, to.privateKey)
+	if err != nil {
+		log.Fatalf("Failed to fee payer sign tx: %v", err)
+	}
+
+	hash, err := c.SendRawTransaction(ctx, tx)
+	if err != nil {
+		if err.Error() == blockchain.ErrNonceTooLow.Error() || err.Error() == blockchain.ErrReplaceUnderpriced.Error() {
+			fmt.Printf("Account(%v) nonce(%v) : Failed to sendTransaction: %v\n", self.GetAddress().String(), nonce, err)
+			fmt.Printf("Account(%v) nonce is added to %v\n", self.GetAddress().String(), nonce+1)
+			self.nonce++
+		} else {
+			fmt.Printf("Account(%v) nonce(%v) : Failed to sendTransaction: %v\n", self.GetAddress().String(), nonce, err)
+		}
+		return hash, gasPrice, err
+	}
+
+	self.nonce++
+
+	return hash, gasPrice, nil
+}
+
+// anchoringGasLimit returns the gas limit of a chain data anchoring tx, which covers the intrinsic gas of the data
+// and the fee delegation.
+func anchoringGasLimit(data []byte) uint64 {
+	return params.TxChainDataAnchoringGas + params.TxGasFeeDelegatedWithRatio + 2*params.ChainDataAnchoringGas*uint64(len(data))
+}
+
+func (self *Account) TransferNewChainDataAnchoringTx(c *client.Client, data []byte) (common.Hash, *big.Int, error) {
+	ctx := context.Background() //context.WithTimeout(context.Background(), 100*time.Second)
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	nonce := self.GetNonce(c)
+
+	signer := types.NewEIP155Signer(chainID)
+	tx, err := types.NewTransactionWithMap(types.TxTypeChainDataAnchoring, map[types.TxValueKeyType]interface{}{
+		types.TxValueKeyNonce:        nonce,
+		types.TxValueKeyFrom:         self.address,
+		types.TxValueKeyGasLimit:     gasLimitOf(c, anchoringGasLimit(data)),
+		types.TxValueKeyGasPrice:     gasPrice,
+		types.TxValueKeyAnchoredData: data,
+	})
+	if err != nil {
+		log.Fatalf("Failed to encode tx: %v", err)
+	}
+
+	err = tx.SignWithKeys(signer, self.privateKey)
+	if err != nil {
+		log.Fatalf("Failed to sign tx: %v", err)
+	}
+
+	hash, err := c.SendRawTransaction(ctx, tx)
+	if err != nil {
+		if err.Error() == blockchain.ErrNonceTooLow.Error() || err.Error() == blockchain.ErrReplaceUnderpriced.Error() {
+			fmt.Printf("Account(%v) nonce(%v) : Failed to sendTransaction: %v\n", self.GetAddress().String(), nonce, err)
+			fmt.Printf("Account(%v) nonce is added to %v\n", self.GetAddress().String(), nonce+1)
+			self.nonce++
+		} else {
+			fmt.Printf("Account(%v) nonce(%v) : Failed to sendTransaction: %v\n", self.GetAddress().String(), nonce, err)
+		}
+		return hash, gasPrice, err
+	}
+
+	self.nonce++
+
+	return hash, gasPrice, nil
+}
+
+func (self *Account) TransferNewFeeDelegatedChainDataAnchoringTx(c *client.Client, to *Account, data []byte) (common.Hash, *big.Int, error) {
+	ctx := context.Background() //context.WithTimeout(context.Background(), 100*time.Second)
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	nonce := self.GetNonce(c)
+
+	signer := types.NewEIP155Signer(chainID)
+	tx, err := types.NewTransactionWithMap(types.TxTypeFeeDelegatedChainDataAnchoring, map[types.TxValueKeyType]interface{}{
+		types.TxValueKeyNonce:        nonce,
+		types.TxValueKeyFrom:         self.address,
+		types.TxValueKeyGasLimit:     gasLimitOf(c, anchoringGasLimit(data)),
+		types.TxValueKeyGasPrice:     gasPrice,
+		types.TxValueKeyAnchoredData: data,
+		types.TxValueKeyFeePayer:     to.address,
+	})
+	if err != nil {
+		log.Fatalf("Failed to encode tx: %v", err)
+	}
+
+	err = tx.SignWithKeys(signer, self.privateKey)
+	if err != nil {
+		log.Fatalf("Failed to sign tx: %v", err)
+	}
+
+	err = tx.SignFeePayerWithKeys(signer, to.privateKey)
+	if err != nil {
+		log.Fatalf("Failed to fee payer sign tx: %v", err)
+	}
+
+	hash, err := c.SendRawTransaction(ctx, tx)
+	if err != nil {
+		if err.Error() == blockchain.ErrNonceTooLow.Error() || err.Error() == blockchain.ErrReplaceUnderpriced.Error() {
+			fmt.Printf("Account(%v) nonce(%v) : Failed to sendTransaction: %v\n", self.GetAddress().String(), nonce, err)
+			fmt.Printf("Account(%v) nonce is added to %v\n", self.GetAddress().String(), nonce+1)
+			self.nonce++
+		} else {
+			fmt.Printf("Account(%v) nonce(%v) : Failed to sendTransaction: %v\n", self.GetAddress().String(), nonce, err)
+		}
+		return hash, gasPrice, err
+	}
+
+	self.nonce++
+
+	return hash, gasPrice, nil
+}
+
+func (self *Account) TransferNewFeeDelegatedChainDataAnchoringWithRatioTx(c *client.Client, to *Account, data []byte, feeRatio types.FeeRatio) (common.Hash, *big.Int, error) {
+	ctx := context.Background() //context.WithTimeout(context.Background(), 100*time.Second)
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	nonce := self.GetNonce(c)
+
+	signer := types.NewEIP155Signer(chainID)
+	tx, err := types.NewTransactionWithMap(types.TxTypeFeeDelegatedChainDataAnchoringWithRatio, map[types.TxValueKeyType]interface{}{
+		types.TxValueKeyNonce:              nonce,
+		types.TxValueKeyFrom:               self.address,
+		types.TxValueKeyGasLimit:           gasLimitOf(c, anchoringGasLimit(data)),
 		types.TxValueKeyGasPrice:           gasPrice,
+		types.TxValueKeyAnchoredData:       data,
 		types.TxValueKeyFeePayer:           to.address,
-		types.TxValueKeyFeeRatioOfFeePayer: types.FeeRatio(30),
+		types.TxValueKeyFeeRatioOfFeePayer: feeRatio,
 	})
 	if err != nil {
 		log.Fatalf("Failed to encode tx: %v", err)
@@ -1544,7 +1746,7 @@ func (self *Account) TransferNewEthereumAccessListTx(c Client, to *Account, valu
 	defer self.mutex.Unlock()
 
 	nonce := self.GetNonce(c)
-	gas := uint64(5000000)
+	gas := gasLimitOf(c, uint64(5000000))
 	var toAddress *common.Address
 	if to != nil {
 		toAddress = &to.address
@@ -1605,7 +1807,7 @@ func (self *Account) TransferNewEthereumDynamicFeeTx(c Client, to *Account, valu
 
 	nonce := self.GetNonce(c)
 
-	gas := uint64(5000000)
+	gas := gasLimitOf(c, uint64(5000000))
 
 	var toAddress *common.Address
 	if to != nil {
@@ -1660,6 +1862,65 @@ func (self *Account) TransferNewEthereumDynamicFeeTx(c Client, to *Account, valu
 	return hash, gasPrice, nil
 }
 
+// SignSetCodeAuthorization signs an EIP-7702 authorization which delegates the account to the delegate.
+// The zero delegate address revokes the delegation. The nonce should be the account nonce when the authorization is applied.
+func (self *Account) SignSetCodeAuthorization(delegate common.Address, nonce uint64) (types.SetCodeAuthorization, error) {
+	return types.SignSetCode(self.privateKey[0], types.SetCodeAuthorization{
+		ChainID: *uint256.MustFromBig(chainID),
+		Address: delegate,
+		Nonce:   nonce,
+	})
+}
+
+// TransferNewEthereumSetCodeTx sends an EIP-7702 set code tx with the given authorizations, which are applied before the call.
+func (self *Account) TransferNewEthereumSetCodeTx(c Client, to *Account, value *big.Int, input []byte, authList []types.SetCodeAuthorization) (common.Hash, *big.Int, error) {
+	ctx := context.Background() //context.WithTimeout(context.Background(), 100*time.Second)
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	nonce := self.GetNonce(c)
+
+	// the intrinsic gas of every authorization, and the delegated call which may call every authorizing EOA in a batch
+	gas := gasLimitOf(c, uint64(200000)+2*params.CallNewAccountGas*uint64(len(authList)))
+
+	signer := types.LatestSignerForChainID(chainID)
+
+	tx := types.NewTx(&types.TxInternalDataEthereumSetCode{
+		ChainID:           uint256.MustFromBig(chainID),
+		AccountNonce:      nonce,
+		Recipient:         to.address,
+		GasLimit:          gas,
+		GasFeeCap:         gasPrice,
+		GasTipCap:         gasPrice,
+		Amount:            value,
+		Payload:           input,
+		AccessList:        types.AccessList{},
+		AuthorizationList: authList,
+	})
+
+	err := tx.SignWithKeys(signer, self.privateKey)
+	if err != nil {
+		log.Fatalf("Failed to sign tx: %v", err)
+	}
+
+	hash, err := c.SendRawTransaction(ctx, tx)
+	if err != nil {
+		if err.Error() == blockchain.ErrNonceTooLow.Error() || err.Error() == blockchain.ErrReplaceUnderpriced.Error() {
+			fmt.Printf("Account(%v) nonce(%v) : Failed to sendTransaction: %v\n", self.GetAddress().String(), nonce, err)
+			fmt.Printf("Account(%v) nonce is added to %v\n", self.GetAddress().String(), nonce+1)
+			self.nonce++
+		} else {
+			fmt.Printf("Account(%v) nonce(%v) : Failed to sendTransaction: %v\n", self.GetAddress().String(), nonce, err)
+		}
+		return hash, gasPrice, err
+	}
+
+	self.nonce++
+
+	return hash, gasPrice, nil
+}
+
 func (self *Account) TransferNewLegacyTxWithEth(c Client, to *Account, value *big.Int, input []byte) (common.Hash, *big.Int, error) {
 	self.mutex.Lock()
 	defer self.mutex.Unlock()
@@ -1667,7 +1928,7 @@ func (self *Account) TransferNewLegacyTxWithEth(c Client, to *Account, value *bi
 	nonce := self.GetNonce(c)
 
 	// Ethereum LegacyTx
-	gas := uint64(100000)
+	gas := gasLimitOf(c, uint64(100000))
 	var tx *types.Transaction
 	if to == nil {
 		gas *= 2
@@ -1819,7 +2080,14 @@ type BidResult struct {
 	RpcOutput map[string]interface{}
 }
 
-func (self *Account) AuctionBid(c *client.Client, auctionEntryPoint, targetContract *Account, targetTxTypeKey string) (common.Hash, common.Hash, *big.Int, error) {
+// AuctionBidParams are the parameters of the bids sent by AuctionBid and AuctionRevertedBid.
+type AuctionBidParams struct {
+	Bid          *big.Int // bid amount in kei
+	Blocks       int      // number of the next blocks bid for the same target tx, only used by AuctionBid
+	CallGasLimit uint64
+}
+
+func (self *Account) AuctionBid(c *client.Client, auctionEntryPoint, targetContract *Account, targetTxTypeKey string, bidParams AuctionBidParams) (common.Hash, common.Hash, *big.Int, error) {
 	self.mutex.Lock()
 	defer self.mutex.Unlock()
 
@@ -1848,7 +2116,7 @@ func (self *Account) AuctionBid(c *client.Client, auctionEntryPoint, targetContr
 		return common.Hash{0}, common.Hash{0}, suggestedGasPrice, err
 	}
 
-	gas := uint64(5000000)
+	gas := bidParams.CallGasLimit
 
 	// Get entrypoint nonce
 	appNonce := getEntrypointNonce(c, self.GetAddress())
@@ -1865,8 +2133,8 @@ func (self *Account) AuctionBid(c *client.Client, auctionEntryPoint, targetContr
 		return common.Hash{0}, common.Hash{0}, suggestedGasPrice, errors.New("this account has already sent a tx for the block")
 	}
 
-	// Create bids for blockNumber +1, +2
-	numOfMergines := 2
+	// Create bids for blockNumber +1, ..., +bidParams.Blocks
+	numOfMergines := bidParams.Blocks
 	var bidInputs []*auctionImpl.BidInput
 	var bidHashes []common.Hash
 
@@ -1881,7 +2149,7 @@ func (self *Account) AuctionBid(c *client.Client, auctionEntryPoint, targetContr
 				Sender:       self.address,
 				To:           targetContract.address,
 				Nonce:        appNonce.Uint64(),
-				Bid:          big.NewInt(2),
+				Bid:          bidParams.Bid,
 				CallGasLimit: gas,
 				Data:         contractCallData,
 			},
@@ -1964,7 +2232,7 @@ func (self *Account) AuctionBid(c *client.Client, auctionEntryPoint, targetContr
 
 // AuctionRevertedBid is responsible for sending reverted bid.
 // Using an invalid nonce in a bid will cause the bid tx to be reverted.
-func (self *Account) AuctionRevertedBid(c *client.Client, auctionEntryPoint, targetContract *Account, targetTxTypeKey string) (common.Hash, common.Hash, *big.Int, error) {
+func (self *Account) AuctionRevertedBid(c *client.Client, auctionEntryPoint, targetContract *Account, targetTxTypeKey string, bidParams AuctionBidParams) (common.Hash, common.Hash, *big.Int, error) {
 	self.mutex.Lock()
 	defer self.mutex.Unlock()
 
@@ -1993,7 +2261,7 @@ func (self *Account) AuctionRevertedBid(c *client.Client, auctionEntryPoint, tar
 		return common.Hash{0}, common.Hash{0}, suggestedGasPrice, err
 	}
 
-	gas := uint64(5000000)
+	gas := bidParams.CallGasLimit
 
 	// Create contract call data (CounterForAuction.incForAuction())
 	contractCallData := TestContractInfos[ContractCounterForTestAuction].GenData(common.Address{}, common.Big0) // 0 means calling incForAuction()
@@ -2015,7 +2283,7 @@ func (self *Account) AuctionRevertedBid(c *client.Client, auctionEntryPoint, tar
 			Sender:       self.address,
 			To:           targetContract.address,
 			Nonce:        math.MaxUint64, // This causes a revert.
-			Bid:          big.NewInt(2),
+			Bid:          bidParams.Bid,
 			CallGasLimit: gas,
 			Data:         contractCallData,
 		},
@@ -2065,7 +2333,7 @@ func (self *Account) TransferNewEthAccessListTxWithEth(c *client.EthClient, to *
 	nonce := self.GetNonce(c)
 
 	// Ethereum AccessListTx
-	gas := uint64(100000)
+	gas := gasLimitOf(c, uint64(100000))
 	var tx *types.Transaction
 	if to == nil {
 		gas *= 2
@@ -2113,7 +2381,7 @@ func (self *Account) TransferNewEthDynamicFeeTxWithEth(c Client, to *Account, va
 	nonce := self.GetNonce(c)
 
 	// Ethereum DynamicFeeTx
-	gas := uint64(100000)
+	gas := gasLimitOf(c, uint64(100000))
 	var tx *types.Transaction
 	if to == nil {
 		gas *= 2
@@ -2159,7 +2427,7 @@ func (self *Account) TransferUnsignedTx(c *client.Client, to *Account, value *bi
 
 	fromAddr := self.GetAddress()
 	toAddr := to.GetAddress()
-	gasLimit := hexutil.Uint64(21000)
+	gasLimit := hexutil.Uint64(gasLimitOf(c, 21000))
 
 	// Initialize empty data and payload for value transfer transaction
 	emptyData := hexutil.Bytes{}
@@ -2237,7 +2505,7 @@ func (a *Account) SmartContractExecutionWithGuaranteeRetry(gCli *client.Client,
 			break
 		}
 		log.Printf("Failed to execute: err=%s", err.Error())
-		time.Sleep(1 * time.Second) // Mostly, the err is `txpool is full`, retry after a while.
+		retryBackoff() // Mostly, the err is `txpool is full`, retry after a while.
 	}
 	ctx, cancelFn := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancelFn()
@@ -2270,7 +2538,7 @@ func (a *Account) TryRunTxSendFunctionWithGuaranteeRetry(gCli *client.Client, al
 		}
 
 		log.Printf("Failed to send tx: err=%s", err.Error())
-		time.Sleep(1 * time.Second)
+		retryBackoff()
 	}
 	ctx, cancelFn := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancelFn()
@@ -0,0 +1,86 @@
+package account
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/client"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/rlp"
+)
+
+// newTestNode starts a JSON-RPC server which accepts every raw tx and returns its hash, and records the txs sent.
+func newTestNode(t *testing.T) (*client.Client, *[]*types.Transaction) {
+	var sent []*types.Transaction
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Failed to decode the request: %v", err)
+			return
+		}
+
+		var result interface{}
+		switch req.Method {
+		case "kaia_getTransactionCount":
+			result = hexutil.Uint64(1)
+		case "kaia_sendRawTransaction":
+			var data hexutil.Bytes
+			if err := json.Unmarshal(req.Params[0], &data); err != nil {
+				t.Errorf("Failed to decode the raw tx: %v", err)
+				return
+			}
+			tx := new(types.Transaction)
+			if err := rlp.DecodeBytes(data, tx); err != nil {
+				t.Errorf("Failed to decode the raw tx: %v", err)
+				return
+			}
+			sent = append(sent, tx)
+			result = tx.Hash()
+		default:
+			t.Errorf("Unexpected method %v", req.Method)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := client.Dial(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to dial the test node: %v", err)
+	}
+	t.Cleanup(c.Close)
+	return c, &sent
+}
+
+func TestTransferNewValueTransferWithCancelTxReturnsCancelHash(t *testing.T) {
+	c, sent := newTestNode(t)
+	from, to := NewAccount(0), NewAccount(1)
+
+	hash, _, err := from.TransferNewValueTransferWithCancelTx(c, to, big.NewInt(1))
+	if err != nil {
+		t.Fatalf("Failed to send the txs: %v", err)
+	}
+	if hash == (common.Hash{}) {
+		t.Fatal("The returned hash is zero")
+	}
+	if len(*sent) != 2 {
+		t.Fatalf("Sent %v txs, want 2", len(*sent))
+	}
+	cancelTx := (*sent)[1]
+	if cancelTx.Type() != types.TxTypeCancel {
+		t.Fatalf("The last tx sent is %v, want %v", cancelTx.Type(), types.TxTypeCancel)
+	}
+	if hash != cancelTx.Hash() {
+		t.Errorf("Returned %v, want the hash of the cancel tx %v", hash.String(), cancelTx.Hash().String())
+	}
+}
@@ -0,0 +1,80 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/kaiachain/kaia/accounts/abi/bind"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/client"
+	"github.com/kaiachain/kaia/common"
+)
+
+// AccountKeyTypeLegacy keeps the key which an account gets implicitly when it receives KAIA for the first time.
+const AccountKeyTypeLegacy AccountKeyType = "legacy"
+
+// CreateNewAccount onboards a brand-new account with the given key type and returns it.
+// Kaia nodes do not accept TxTypeAccountCreation anymore, so the account is created by a value transfer.
+// Unless the key type is legacy, the new account then updates its own key, which requires the value to cover the fee.
+// It also returns the hash of the value transfer which funds the account. For the legacy key type, the value transfer is not waited for,
+// so the returned account may not exist on chain until the tx of the hash is mined.
+func (self *Account) CreateNewAccount(c *client.Client, keyType AccountKeyType, value *big.Int) (*Account, common.Hash, error) {
+	newAcc := NewAccount(0)
+
+	hash, _, err := self.TransferNewValueTransferTx(c, newAcc, value)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	if keyType == AccountKeyTypeLegacy {
+		return newAcc, hash, nil
+	}
+
+	if err := waitReceipt(c, hash, 60*time.Second); err != nil {
+		return nil, common.Hash{}, err
+	}
+
+	newKey, newPrivateKeys := newAccountKey(keyType, randomKeys)
+	tx, err := newAcc.sendAccountUpdateTx(c, newKey)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancelFn()
+
+	receipt, err := bind.WaitMined(ctx, c, tx)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, common.Hash{}, fmt.Errorf("account update failed, txHash=%s", tx.Hash().String())
+	}
+
+	newAcc.mutex.Lock()
+	newAcc.privateKey = newPrivateKeys
+	newAcc.mutex.Unlock()
+
+	return newAcc, hash, nil
+}
+
+// waitReceipt polls the receipt of the given hash until it is mined successfully or the timeout passes.
+func waitReceipt(c *client.Client, hash common.Hash, timeout time.Duration) error {
+	ctx := context.Background()
+	deadline := time.Now().Add(timeout)
+	for {
+		receipt, _ := c.TransactionReceipt(ctx, hash)
+		if receipt != nil {
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				return fmt.Errorf("tx mined but failed, txHash=%s", hash.String())
+			}
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return errors.New("Time out : It took more than " + timeout.String() + " to make a block ")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
@@ -0,0 +1,241 @@
+package account
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/kaiachain/kaia/accounts/abi/bind"
+	"github.com/kaiachain/kaia/blockchain"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/blockchain/types/accountkey"
+	"github.com/kaiachain/kaia/client"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/networks/rpc"
+	"github.com/kaiachain/kaia/params"
+)
+
+// AccountKeyType defines the key type which the test accounts are migrated to by the key type test cases.
+type AccountKeyType string
+
+const (
+	AccountKeyTypePublic    AccountKeyType = "public"
+	AccountKeyTypeMultiSig  AccountKeyType = "multisig"
+	AccountKeyTypeRoleBased AccountKeyType = "roleBased"
+)
+
+var (
+	multiSigThreshold uint = 2
+	multiSigNumKeys        = 3
+
+	// roleKeyType is the key type of each role in AccountKeyRoleBased.
+	roleKeyType = AccountKeyTypePublic
+)
+
+// SetMultiSigConfig sets the threshold and the number of keys of AccountKeyWeightedMultiSig.
+// Every key has weight 1, so the threshold is the number of signatures required.
+func SetMultiSigConfig(threshold uint, numKeys int) {
+	if numKeys <= 0 || uint64(numKeys) > accountkey.MaxNumKeysForMultiSig {
+		log.Fatalf("the number of multisig keys should be between 1 and %v, but it is %v", accountkey.MaxNumKeysForMultiSig, numKeys)
+	}
+	if threshold == 0 || threshold > uint(numKeys) {
+		log.Fatalf("the multisig threshold should be between 1 and %v, but it is %v", numKeys, threshold)
+	}
+	multiSigThreshold = threshold
+	multiSigNumKeys = numKeys
+}
+
+// SetRoleKeyType sets the key type of each role in AccountKeyRoleBased. Only public and multisig are allowed.
+func SetRoleKeyType(keyType AccountKeyType) {
+	if keyType != AccountKeyTypePublic && keyType != AccountKeyTypeMultiSig {
+		log.Fatalf("the role key type should be %v or %v, but it is %v", AccountKeyTypePublic, AccountKeyTypeMultiSig, keyType)
+	}
+	roleKeyType = keyType
+}
+
+// keySource returns the i-th private key of a new account key.
+type keySource func(i int) *ecdsa.PrivateKey
+
+// randomKeys generates every key randomly.
+func randomKeys(int) *ecdsa.PrivateKey {
+	k, err := crypto.GenerateKey()
+	if err != nil {
+		log.Fatalf("crypto.GenerateKey() : Failed to generateKey %v", err)
+	}
+	return k
+}
+
+// hdKeys derives the keys which the index-th account in the accList of the given slave is migrated to,
+// so that a rerun with the same wallet recovers the migrated accounts.
+func hdKeys(w *HDWallet, slaveIndex int, accList AccList, index int) keySource {
+	return func(i int) *ecdsa.PrivateKey {
+		k, err := w.DeriveKey(MigratedKeyDerivationPath(slaveIndex, accList, index, i))
+		if err != nil {
+			log.Fatalf("Failed to derive the migrated key: %v", err)
+		}
+		return k
+	}
+}
+
+// newAccountKey creates the private keys from the source and the account key which consists of them.
+// Role-based keys use the same key set for every role, so the account can keep signing with a single key set.
+func newAccountKey(keyType AccountKeyType, source keySource) (accountkey.AccountKey, []*ecdsa.PrivateKey) {
+	var numKeys int
+	switch keyType {
+	case AccountKeyTypePublic:
+		numKeys = 1
+	case AccountKeyTypeMultiSig:
+		numKeys = multiSigNumKeys
+	case AccountKeyTypeRoleBased:
+		roleKey, keys := newAccountKey(roleKeyType, source)
+		roleKeys := make([]accountkey.AccountKey, accountkey.RoleLast)
+		for i := range roleKeys {
+			roleKeys[i] = roleKey.DeepCopy()
+		}
+		return accountkey.NewAccountKeyRoleBasedWithValues(roleKeys), keys
+	default:
+		log.Fatalf("unknown account key type: %v", keyType)
+	}
+
+	keys := make([]*ecdsa.PrivateKey, numKeys)
+	for i := range keys {
+		keys[i] = source(i)
+	}
+
+	if keyType == AccountKeyTypePublic {
+		return accountkey.NewAccountKeyPublicWithValue(&keys[0].PublicKey), keys
+	}
+	weightedKeys := make(accountkey.WeightedPublicKeys, len(keys))
+	for i, k := range keys {
+		weightedKeys[i] = accountkey.NewWeightedPublicKey(1, (*accountkey.PublicKeySerializable)(&k.PublicKey))
+	}
+	return accountkey.NewAccountKeyWeightedMultiSigWithValues(multiSigThreshold, weightedKeys), keys
+}
+
+// sigValidationGas returns the additional intrinsic gas required to validate the signatures of this account.
+func (self *Account) sigValidationGas() uint64 {
+	if len(self.privateKey) <= 1 {
+		return 0
+	}
+	return uint64(len(self.privateKey)-1) * params.TxValidationGasPerKey
+}
+
+// UpdateAccountKeyWithGuaranteeRetry migrates the account to the given key type with a TxTypeAccountUpdate.
+// Once the update is mined, the account signs the following transactions with the new keys.
+// If rpcCli is given and the account already has the new key on chain, e.g. from a previous run, the keys are adopted without an update.
+func (self *Account) UpdateAccountKeyWithGuaranteeRetry(gCli *client.Client, rpcCli *rpc.Client, keyType AccountKeyType, source keySource) {
+	newKey, newPrivateKeys := newAccountKey(keyType, source)
+
+	if rpcCli != nil {
+		current := accountkey.NewAccountKeySerializer()
+		if err := rpcCli.CallContext(context.Background(), current, "kaia_getAccountKey", self.address, "latest"); err != nil {
+			log.Printf("Failed to get the account key of %v: %v", self.address.String(), err)
+		} else if current.GetKey().Equal(newKey) {
+			self.mutex.Lock()
+			self.privateKey = newPrivateKeys
+			self.mutex.Unlock()
+			return
+		}
+	}
+
+	var (
+		err    error
+		lastTx *types.Transaction
+	)
+
+	for {
+		lastTx, err = self.sendAccountUpdateTx(gCli, newKey)
+		if err == nil {
+			break
+		}
+		log.Printf("Failed to update account key: err=%s", err.Error())
+		retryBackoff() // Mostly, the err is `txpool is full`, retry after a while.
+	}
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancelFn()
+
+	receipt, err := bind.WaitMined(ctx, gCli, lastTx)
+	if err != nil || (receipt != nil && receipt.Status == 0) {
+		// shouldn't happen. must check if the key is correct.
+		log.Fatalf("tx mined but failed, err=%s, txHash=%s", err, lastTx.Hash().String())
+	}
+
+	self.mutex.Lock()
+	self.privateKey = newPrivateKeys
+	self.mutex.Unlock()
+}
+
+func (self *Account) sendAccountUpdateTx(c *client.Client, newKey accountkey.AccountKey) (*types.Transaction, error) {
+	ctx := context.Background()
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	nonce := self.GetNonce(c)
+
+	signer := types.NewEIP155Signer(chainID)
+	tx, err := types.NewTransactionWithMap(types.TxTypeAccountUpdate, map[types.TxValueKeyType]interface{}{
+		types.TxValueKeyNonce:      nonce,
+		types.TxValueKeyFrom:       self.address,
+		types.TxValueKeyGasLimit:   gasLimitOf(c, uint64(1000000)+self.sigValidationGas()),
+		types.TxValueKeyGasPrice:   gasPrice,
+		types.TxValueKeyAccountKey: newKey,
+	})
+	if err != nil {
+		log.Fatalf("Failed to encode tx: %v", err)
+	}
+
+	err = tx.SignWithKeys(signer, self.privateKey)
+	if err != nil {
+		log.Fatalf("Failed to sign tx: %v", err)
+	}
+
+	_, err = c.SendRawTransaction(ctx, tx)
+	if err != nil {
+		if err.Error() == blockchain.ErrNonceTooLow.Error() || err.Error() == blockchain.ErrReplaceUnderpriced.Error() {
+			fmt.Printf("Account(%v) nonce(%v) : Failed to sendTransaction: %v\n", self.GetAddress().String(), nonce, err)
+			fmt.Printf("Account(%v) nonce is added to %v\n", self.GetAddress().String(), nonce+1)
+			self.nonce++
+		} else {
+			fmt.Printf("Account(%v) nonce(%v) : Failed to sendTransaction: %v\n", self.GetAddress().String(), nonce, err)
+		}
+		return tx, err
+	}
+
+	self.nonce++
+
+	return tx, nil
+}
+
+// MigrateAccountKeys migrates every account of the accList to the key type.
+// If the HD wallet is set, the new keys are derived from it and the accounts migrated by a previous run keep their keys.
+// Otherwise the new keys are generated randomly.
+func (a *AccGroup) MigrateAccountKeys(gCli *client.Client, endpoint string, accList AccList, keyType AccountKeyType, maxConcurrency int) {
+	accs := a.GetAccListByName(accList)
+	indexes := make(map[*Account]int, len(accs))
+	for i, acc := range accs {
+		indexes[acc] = i
+	}
+
+	var rpcCli *rpc.Client
+	if a.hdWallet != nil {
+		var err error
+		if rpcCli, err = rpc.Dial(endpoint); err != nil {
+			log.Fatalf("Failed to connect to %v: %v", endpoint, err)
+		}
+		defer rpcCli.Close()
+	}
+
+	log.Printf("Start migrating %d test account(s) to %v keys", len(accs), keyType)
+	ConcurrentTransactionSend(accs, maxConcurrency, func(acc *Account) {
+		source := keySource(randomKeys)
+		if a.hdWallet != nil {
+			source = hdKeys(a.hdWallet, a.slaveIndex, accList, indexes[acc])
+		}
+		acc.UpdateAccountKeyWithGuaranteeRetry(gCli, rpcCli, keyType, source)
+	})
+	log.Printf("Finished migrating %d test account(s) to %v keys", len(accs), keyType)
+}
@@ -0,0 +1,175 @@
+package account
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kaiachain/kaia/blockchain"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/client"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/kaiax/auction"
+	auctionImpl "github.com/kaiachain/kaia/kaiax/auction/impl"
+)
+
+// CompetitiveBidParams are the parameters of the competing bids sent by AuctionCompetitiveBids.
+type CompetitiveBidParams struct {
+	Bids         []*big.Int // bid amount in kei of every searcher
+	Blocks       int        // number of the next blocks bid for the same target tx
+	CallGasLimit uint64
+	Jitter       time.Duration // max random delay of the bids of every searcher
+}
+
+// SearcherBid is the result of the bids of a searcher on a target tx.
+type SearcherBid struct {
+	Searcher *Account
+	Bid      *big.Int
+	Nonce    uint64 // nonce of the searcher in the AuctionEntryPoint
+	SentAt   time.Time
+	Elapsed  time.Duration // time to send the bids of the searcher
+	Accepted bool          // true if any bid of the searcher is added to the bid pool
+	Err      error         // error of the last rejected bid, e.g. auction.ErrLowBid if a higher or an earlier equal bid is in the pool
+}
+
+// AuctionCompetitiveBids sends a target tx of the account and the bids of all searchers on it for the blocks after blockNumber.
+// Every searcher sends its bids after a random delay up to the jitter, concurrently with the others, so that the bid pool picks the winner.
+// The account and the searchers are locked until the bids are sent. The account should not be one of the searchers.
+func (self *Account) AuctionCompetitiveBids(c *client.Client, auctionEntryPoint, targetContract *Account, targetTxTypeKey string, searchers []*Account, blockNumber *big.Int, bidParams CompetitiveBidParams) (*types.Transaction, []*SearcherBid, error) {
+	unlock := lockAccounts(append([]*Account{self}, searchers...))
+	defer unlock()
+
+	// create tmpAccount
+	tmpAccount := NewAccount(0)
+
+	/* ---------------- Generate target tx ---------------- */
+	nonce := self.GetNonce(c)
+	ctx := context.Background()
+	suggestedGasPrice, err := c.SuggestGasPrice(ctx)
+	if err != nil {
+		fmt.Printf("Failed to fetch suggest gas price: %v\n", err.Error())
+		return nil, nil, err
+	}
+
+	targetTxType := TargetTxTypeList[targetTxTypeKey]
+	targetTx := targetTxType.GenerateTx(c, self, tmpAccount, nonce, suggestedGasPrice)
+	if targetTx == nil {
+		return nil, nil, errors.New("failed to generate target tx")
+	}
+	if self.isLastBlocknumSentTx(blockNumber.Uint64()) {
+		return nil, nil, errors.New("this account has already sent a tx for the block")
+	}
+
+	/* ---------------- Send bids ------------------------- */
+	if err := targetTxType.PreSendBid(c, self, tmpAccount, nonce, suggestedGasPrice); err != nil {
+		// If PreSendBid fails, the remaining steps do not need to be performed.
+		return nil, nil, err
+	}
+
+	// Create contract call data (CounterForAuction.incForAuction())
+	contractCallData := TestContractInfos[ContractCounterForTestAuction].GenData(common.Address{}, common.Big0) // 0 means calling incForAuction()
+
+	searcherBids := make([]*SearcherBid, len(searchers))
+	bidInputs := make([][]*auctionImpl.BidInput, len(searchers))
+	for i, searcher := range searchers {
+		searcherBids[i] = &SearcherBid{
+			Searcher: searcher,
+			Bid:      bidParams.Bids[i],
+			Nonce:    getEntrypointNonce(c, searcher.GetAddress()).Uint64(),
+		}
+		for j := 1; j <= bidParams.Blocks; j++ {
+			bid := &auction.Bid{
+				BidData: auction.BidData{
+					TargetTxHash: targetTx.Hash(),
+					BlockNumber:  blockNumber.Uint64() + uint64(j),
+					Sender:       searcher.address,
+					To:           targetContract.address,
+					Nonce:        searcherBids[i].Nonce,
+					Bid:          bidParams.Bids[i],
+					CallGasLimit: bidParams.CallGasLimit,
+					Data:         contractCallData,
+				},
+			}
+			searcherSignedBid, err := searcher.signAuctionBidAsSearcher(bid, auctionEntryPoint)
+			if err != nil {
+				return nil, nil, err
+			}
+			bidInput, err := Auctioneer.signAuctionBidAsAuctioneer(searcherSignedBid, toRlp(targetTx))
+			if err != nil {
+				return nil, nil, err
+			}
+			bidInputs[i] = append(bidInputs[i], bidInput)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := range searchers {
+		wg.Add(1)
+		go func(searcherBid *SearcherBid, bidInputs []*auctionImpl.BidInput) {
+			defer wg.Done()
+			if bidParams.Jitter > 0 {
+				time.Sleep(time.Duration(rand.Int63n(int64(bidParams.Jitter))))
+			}
+			searcherBid.SentAt = time.Now()
+			for _, bidInput := range bidInputs {
+				rpcOutput, err := c.SendAuctionTx(context.Background(), *bidInput)
+				if err == nil && rpcOutput[auctionImpl.RPC_AUCTION_ERROR_PROP] != nil {
+					err = errors.New(rpcOutput[auctionImpl.RPC_AUCTION_ERROR_PROP].(string))
+				}
+				if err != nil {
+					searcherBid.Err = err
+				} else {
+					searcherBid.Accepted = true
+				}
+			}
+			searcherBid.Elapsed = time.Since(searcherBid.SentAt)
+		}(searcherBids[i], bidInputs[i])
+	}
+	wg.Wait()
+
+	/* ---------------- Handle rpc output -------------------------- */
+	var numNonceTooLowErr int
+	var submitErr error
+	for _, searcherBid := range searcherBids {
+		if searcherBid.Accepted {
+			targetTxType.PostSendBid(c, self, tmpAccount, nonce, suggestedGasPrice, blockNumber)
+			return targetTx, searcherBids, nil
+		}
+		submitErr = searcherBid.Err
+		if submitErr.Error() == blockchain.ErrNonceTooLow.Error() || submitErr.Error() == blockchain.ErrReplaceUnderpriced.Error() {
+			numNonceTooLowErr++
+		}
+	}
+
+	// If all the bids are failed due to nonce too low, add nonce and return error.
+	if numNonceTooLowErr == len(searcherBids) {
+		fmt.Printf("Account(%v) nonce(%v) : Failed to sendTransaction: %v\n", self.GetAddress().String(), nonce, submitErr)
+		fmt.Printf("Account(%v) nonce is added to %v\n", self.GetAddress().String(), nonce+1)
+		self.nonce++
+	}
+	return targetTx, searcherBids, fmt.Errorf("failed to send auction bid: %v", submitErr)
+}
+
+// lockAccounts locks the accounts in the order of the addresses, so that two callers sharing some accounts do not deadlock.
+// It returns the func which unlocks them.
+func lockAccounts(accs []*Account) func() {
+	sorted := make([]*Account, len(accs))
+	copy(sorted, accs)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].address.Bytes(), sorted[j].address.Bytes()) < 0
+	})
+	for _, acc := range sorted {
+		acc.mutex.Lock()
+	}
+	return func() {
+		for _, acc := range sorted {
+			acc.mutex.Unlock()
+		}
+	}
+}
@@ -0,0 +1,305 @@
+package account
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	kaia "github.com/kaiachain/kaia"
+	auctionDepositVaultContracts "github.com/kaiachain/kaia-load-tester/klayslave/account/contracts/auctionDepositVault"
+	auctionEntryPointContracts "github.com/kaiachain/kaia-load-tester/klayslave/account/contracts/auctionEntryPoint"
+	auctionFeeVaultContracts "github.com/kaiachain/kaia-load-tester/klayslave/account/contracts/auctionFeeVault"
+	"github.com/kaiachain/kaia/accounts/abi"
+	"github.com/kaiachain/kaia/accounts/abi/bind"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/client"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+)
+
+// AuctionVaults are the addresses of the auction contracts, read from the AuctionEntryPoint.
+type AuctionVaults struct {
+	EntryPoint   common.Address
+	DepositVault common.Address
+	FeeVault     common.Address
+}
+
+// AuctionBidOutcome is what the tx after a target tx did with a bid, found by the events of the auction contracts.
+type AuctionBidOutcome struct {
+	BidTx      common.Hash    // empty if the tx after the target tx is not a bid tx, i.e. no bid won
+	Searcher   common.Address // sender of the bid, decoded from the input of the bid tx
+	Bid        *big.Int       // bid in the input of the bid tx
+	Reverted   bool           // the bid tx reverted, so it emitted no event
+	Executed   bool           // the bid used the nonce of the searcher
+	CallFailed bool           // the bid is executed but its call failed
+	TakenBid   *big.Int       // bid taken from the deposit of the searcher
+	TakenGas   *big.Int       // gas fee taken from the deposit of the searcher for the proposer
+	BidTxFee   *big.Int       // gas used by the bid tx times its effective gas price
+	FeeDeposit *big.Int       // bid deposited into the fee vault
+	Payback    *big.Int       // searcher and validator paybacks of the deposited bid
+
+	// AccountingChecked is false if the balances of the vaults could not be read, e.g. the node does not have the state of the previous block.
+	AccountingChecked bool
+	// AccountingErr is the mismatch of the events, the bid and the balance changes of the vaults.
+	AccountingErr error
+}
+
+// GetAuctionVaults reads the deposit vault of the AuctionEntryPoint and the fee vault of the deposit vault.
+func GetAuctionVaults(c *client.Client, auctionEntryPoint *Account) (AuctionVaults, error) {
+	vaults := AuctionVaults{EntryPoint: auctionEntryPoint.GetAddress()}
+	entryPoint, err := auctionEntryPointContracts.NewAuctionEntryPointCaller(vaults.EntryPoint, c)
+	if err != nil {
+		return vaults, err
+	}
+	if vaults.DepositVault, err = entryPoint.DepositVault(&bind.CallOpts{}); err != nil {
+		return vaults, fmt.Errorf("failed to read the deposit vault: %v", err)
+	}
+	depositVault, err := auctionDepositVaultContracts.NewAuctionDepositVaultCaller(vaults.DepositVault, c)
+	if err != nil {
+		return vaults, err
+	}
+	if vaults.FeeVault, err = depositVault.AuctionFeeVault(&bind.CallOpts{}); err != nil {
+		return vaults, fmt.Errorf("failed to read the fee vault: %v", err)
+	}
+	return vaults, nil
+}
+
+// GetAuctionBidOutcome finds the bid tx at bidTxIndex of the block, which the proposer places right after the target tx, and what it did.
+// The taken bid and the fee deposit of an executed bid should be the bid, and the balances of the fee vault and of the deposit of the searcher
+// should change by the events of the whole block.
+func GetAuctionBidOutcome(c *client.Client, vaults AuctionVaults, blockHash common.Hash, blockNumber *big.Int, bidTxIndex uint) (*AuctionBidOutcome, error) {
+	ctx := context.Background()
+	outcome := &AuctionBidOutcome{}
+
+	bidTx, err := c.TransactionInBlock(ctx, blockHash, bidTxIndex)
+	if errors.Is(err, kaia.NotFound) {
+		return outcome, nil // the target tx is the last tx of the block
+	}
+	if err != nil {
+		return nil, err
+	}
+	if bidTx.To() == nil || *bidTx.To() != vaults.EntryPoint {
+		return outcome, nil
+	}
+
+	entryPointAbi, err := auctionEntryPointContracts.AuctionEntryPointMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	method := entryPointAbi.Methods["call"]
+	input := bidTx.Data()
+	if len(input) < 4 || !bytes.Equal(input[:4], method.ID) {
+		return outcome, nil
+	}
+	args, err := method.Inputs.Unpack(input[4:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the bid tx: %v", err)
+	}
+	auctionTx := abi.ConvertType(args[0], new(auctionEntryPointContracts.IAuctionEntryPointAuctionTx)).(*auctionEntryPointContracts.IAuctionEntryPointAuctionTx)
+	outcome.BidTx = bidTx.Hash()
+	outcome.Searcher = auctionTx.Sender
+	outcome.Bid = auctionTx.Bid
+
+	receipt, err := c.TransactionReceiptRpcOutput(ctx, outcome.BidTx)
+	if err != nil {
+		return nil, err
+	}
+	status, _ := receipt["status"].(string)
+	gasUsed, _ := receipt["gasUsed"].(string)
+	effectiveGasPrice, _ := receipt["effectiveGasPrice"].(string)
+	outcome.BidTxFee = new(big.Int).Mul(hexToBig(gasUsed), hexToBig(effectiveGasPrice))
+	if status != "0x1" {
+		outcome.Reverted = true
+		return outcome, nil
+	}
+
+	logs, err := c.FilterLogs(ctx, kaia.FilterQuery{
+		BlockHash: &blockHash,
+		Addresses: []common.Address{vaults.EntryPoint, vaults.DepositVault, vaults.FeeVault},
+	})
+	if err != nil {
+		return nil, err
+	}
+	events, err := parseAuctionLogs(logs, vaults)
+	if err != nil {
+		return nil, err
+	}
+
+	// The events of the bid tx
+	outcome.TakenBid, outcome.TakenGas, outcome.FeeDeposit, outcome.Payback = new(big.Int), new(big.Int), new(big.Int), new(big.Int)
+	for _, e := range events {
+		if e.txIndex != bidTxIndex {
+			continue
+		}
+		switch e.name {
+		case "UseNonce":
+			outcome.Executed = e.searcher == outcome.Searcher
+		case "CallFailed":
+			outcome.CallFailed = true
+		case "TakenBid":
+			outcome.TakenBid.Add(outcome.TakenBid, e.amount)
+		case "TakenGas":
+			outcome.TakenGas.Add(outcome.TakenGas, e.amount)
+		case "FeeDeposit":
+			outcome.FeeDeposit.Add(outcome.FeeDeposit, e.amount)
+			outcome.Payback.Add(outcome.Payback, e.payback)
+		}
+	}
+	if !outcome.Executed {
+		return outcome, nil
+	}
+	if outcome.TakenBid.Cmp(outcome.Bid) != 0 || outcome.FeeDeposit.Cmp(outcome.Bid) != 0 {
+		outcome.AccountingErr = fmt.Errorf("bid %v, but %v is taken from the deposit and %v is deposited into the fee vault", outcome.Bid, outcome.TakenBid, outcome.FeeDeposit)
+		return outcome, nil
+	}
+
+	// The balance changes by the events of the block
+	feeVaultDelta, depositDelta := new(big.Int), new(big.Int)
+	for _, e := range events {
+		switch e.name {
+		case "FeeDeposit":
+			feeVaultDelta.Add(feeVaultDelta, e.amount)
+			feeVaultDelta.Sub(feeVaultDelta, e.payback)
+		case "FeeWithdrawal":
+			feeVaultDelta.Sub(feeVaultDelta, e.amount)
+		case "TakenBid", "TakenGas":
+			if e.searcher == outcome.Searcher {
+				depositDelta.Sub(depositDelta, e.amount)
+			}
+		case "VaultDeposit":
+			if e.searcher == outcome.Searcher {
+				depositDelta.Add(depositDelta, e.amount)
+			}
+		}
+	}
+
+	parentNumber := new(big.Int).Sub(blockNumber, common.Big1)
+	feeVaultBefore, err := c.BalanceAt(ctx, vaults.FeeVault, parentNumber)
+	if err != nil {
+		return outcome, nil
+	}
+	feeVaultAfter, err := c.BalanceAt(ctx, vaults.FeeVault, blockNumber)
+	if err != nil {
+		return outcome, nil
+	}
+	depositVault, err := auctionDepositVaultContracts.NewAuctionDepositVaultCaller(vaults.DepositVault, c)
+	if err != nil {
+		return nil, err
+	}
+	depositBefore, err := depositVault.DepositBalances(&bind.CallOpts{BlockNumber: parentNumber}, outcome.Searcher)
+	if err != nil {
+		return outcome, nil
+	}
+	depositAfter, err := depositVault.DepositBalances(&bind.CallOpts{BlockNumber: blockNumber}, outcome.Searcher)
+	if err != nil {
+		return outcome, nil
+	}
+	outcome.AccountingChecked = true
+
+	if actual := new(big.Int).Sub(feeVaultAfter, feeVaultBefore); actual.Cmp(feeVaultDelta) != 0 {
+		outcome.AccountingErr = fmt.Errorf("the fee vault balance changed by %v in block %v, but its events sum to %v", actual, blockNumber, feeVaultDelta)
+	} else if actual := new(big.Int).Sub(depositAfter, depositBefore); actual.Cmp(depositDelta) != 0 {
+		outcome.AccountingErr = fmt.Errorf("the deposit of %v changed by %v in block %v, but its events sum to %v", outcome.Searcher.String(), actual, blockNumber, depositDelta)
+	}
+	return outcome, nil
+}
+
+// auctionEvent is an event of the auction contracts which GetAuctionBidOutcome uses.
+type auctionEvent struct {
+	name     string
+	txIndex  uint
+	searcher common.Address
+	amount   *big.Int
+	payback  *big.Int // searcher and validator paybacks of a FeeDeposit
+}
+
+// parseAuctionLogs parses the logs of the auction contracts, skipping the events which GetAuctionBidOutcome does not use.
+func parseAuctionLogs(logs []types.Log, vaults AuctionVaults) ([]auctionEvent, error) {
+	entryPointAbi, err := auctionEntryPointContracts.AuctionEntryPointMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	depositVaultAbi, err := auctionDepositVaultContracts.AuctionDepositVaultMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	feeVaultAbi, err := auctionFeeVaultContracts.AuctionFeeVaultMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	entryPoint, err := auctionEntryPointContracts.NewAuctionEntryPointFilterer(vaults.EntryPoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	depositVault, err := auctionDepositVaultContracts.NewAuctionDepositVaultFilterer(vaults.DepositVault, nil)
+	if err != nil {
+		return nil, err
+	}
+	feeVault, err := auctionFeeVaultContracts.NewAuctionFeeVaultFilterer(vaults.FeeVault, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []auctionEvent
+	for _, l := range logs {
+		if len(l.Topics) == 0 {
+			continue
+		}
+		e := auctionEvent{txIndex: l.TxIndex}
+		var err error
+		switch {
+		case l.Address == vaults.EntryPoint && l.Topics[0] == entryPointAbi.Events["UseNonce"].ID:
+			var ev *auctionEntryPointContracts.AuctionEntryPointUseNonce
+			if ev, err = entryPoint.ParseUseNonce(l); err == nil {
+				e.name, e.searcher = "UseNonce", ev.Searcher
+			}
+		case l.Address == vaults.EntryPoint && l.Topics[0] == entryPointAbi.Events["CallFailed"].ID:
+			var ev *auctionEntryPointContracts.AuctionEntryPointCallFailed
+			if ev, err = entryPoint.ParseCallFailed(l); err == nil {
+				e.name, e.searcher = "CallFailed", ev.Sender
+			}
+		case l.Address == vaults.DepositVault && l.Topics[0] == depositVaultAbi.Events["TakenBid"].ID:
+			var ev *auctionDepositVaultContracts.AuctionDepositVaultTakenBid
+			if ev, err = depositVault.ParseTakenBid(l); err == nil {
+				e.name, e.searcher, e.amount = "TakenBid", ev.Searcher, ev.Amount
+			}
+		case l.Address == vaults.DepositVault && l.Topics[0] == depositVaultAbi.Events["TakenGas"].ID:
+			var ev *auctionDepositVaultContracts.AuctionDepositVaultTakenGas
+			if ev, err = depositVault.ParseTakenGas(l); err == nil {
+				e.name, e.searcher, e.amount = "TakenGas", ev.Searcher, ev.GasAmount
+			}
+		case l.Address == vaults.DepositVault && l.Topics[0] == depositVaultAbi.Events["VaultDeposit"].ID:
+			var ev *auctionDepositVaultContracts.AuctionDepositVaultVaultDeposit
+			if ev, err = depositVault.ParseVaultDeposit(l); err == nil {
+				e.name, e.searcher, e.amount = "VaultDeposit", ev.Searcher, ev.Amount
+			}
+		case l.Address == vaults.FeeVault && l.Topics[0] == feeVaultAbi.Events["FeeDeposit"].ID:
+			var ev *auctionFeeVaultContracts.AuctionFeeVaultFeeDeposit
+			if ev, err = feeVault.ParseFeeDeposit(l); err == nil {
+				e.name, e.amount, e.payback = "FeeDeposit", ev.Amount, new(big.Int).Add(ev.PaybackAmount, ev.ValidatorPaybackAmount)
+			}
+		case l.Address == vaults.FeeVault && l.Topics[0] == feeVaultAbi.Events["FeeWithdrawal"].ID:
+			var ev *auctionFeeVaultContracts.AuctionFeeVaultFeeWithdrawal
+			if ev, err = feeVault.ParseFeeWithdrawal(l); err == nil {
+				e.name, e.amount = "FeeWithdrawal", ev.Amount
+			}
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the log of %v: %v", l.Address.String(), err)
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+func hexToBig(s string) *big.Int {
+	v, err := hexutil.DecodeBig(s)
+	if err != nil {
+		return new(big.Int)
+	}
+	return v
+}
@@ -0,0 +1,29 @@
+; ComputationLoop loops as many times as requested by the calldata. It has no ABI.
+; The runtime code below is assembled with the constructor
+;   PUSH2 <runtime size> DUP1 PUSH2 13 PUSH1 0 CODECOPY PUSH1 0 RETURN
+; into the bytecode of createComputationLoopContractInfo.
+;
+; calldata: iterations (32 bytes)
+; Every iteration has the same opcodes with a MULMOD, so that the gas and the computation cost grow linearly.
+
+    PUSH1 0x00
+    CALLDATALOAD        ; [i]
+loop:
+    JUMPDEST
+    DUP1
+    ISZERO
+    PUSH2 end
+    JUMPI
+    PUSH1 0x01
+    SWAP1
+    SUB                 ; [i-1]
+    DUP1
+    DUP1
+    DUP1
+    MULMOD
+    POP
+    PUSH2 loop
+    JUMP
+end:
+    JUMPDEST
+    STOP
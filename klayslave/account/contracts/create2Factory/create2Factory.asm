@@ -0,0 +1,36 @@
+; Create2Factory deploys the initcode in the calldata by CREATE2. It has no ABI.
+; The runtime code below is assembled with the constructor
+;   PUSH2 <runtime size> DUP1 PUSH2 13 PUSH1 0 CODECOPY PUSH1 0 RETURN
+; into the bytecode of createCreate2FactoryContractInfo.
+;
+; calldata: salt (32 bytes) | initcode
+; It returns the address of the new contract, and reverts if the creation fails, e.g. the address is already used.
+
+    CALLDATASIZE
+    PUSH1 0x20
+    SWAP1
+    SUB                 ; [size]
+    DUP1
+    PUSH1 0x20
+    PUSH1 0x00
+    CALLDATACOPY        ; memory[0:size] = initcode
+    PUSH1 0x00
+    CALLDATALOAD        ; [size, salt]
+    SWAP1
+    PUSH1 0x00
+    PUSH1 0x00
+    CREATE2             ; [address]
+    DUP1
+    ISZERO
+    PUSH2 fail
+    JUMPI
+    PUSH1 0x00
+    MSTORE
+    PUSH1 0x20
+    PUSH1 0x00
+    RETURN
+fail:
+    JUMPDEST
+    PUSH1 0x00
+    DUP1
+    REVERT
@@ -0,0 +1,41 @@
+; EIP7702Delegate is the code which the EOAs of the EIP-7702 TCs delegate to. It has no ABI.
+; The runtime code below is assembled with the constructor
+;   PUSH2 <runtime size> DUP1 PUSH2 13 PUSH1 0 CODECOPY PUSH1 0 RETURN
+; into the bytecode of createEIP7702DelegateContractInfo.
+;
+; calldata: target (32 bytes) | target (32 bytes) | ...
+; Every call increments the counter in slot 0 of the delegated EOA, and calls every target without value and data
+; as a batch. A target which is also a delegated EOA increments its own counter.
+
+    PUSH1 0x00
+    SLOAD
+    PUSH1 0x01
+    ADD
+    PUSH1 0x00
+    SSTORE              ; counter++
+    PUSH1 0x00          ; [offset]
+loop:
+    JUMPDEST
+    CALLDATASIZE
+    DUP2
+    LT                  ; [offset, offset < size]
+    ISZERO
+    PUSH2 end
+    JUMPI
+    PUSH1 0x00          ; retSize
+    PUSH1 0x00          ; retOffset
+    PUSH1 0x00          ; argsSize
+    PUSH1 0x00          ; argsOffset
+    PUSH1 0x00          ; value
+    DUP6
+    CALLDATALOAD        ; target
+    GAS
+    CALL
+    POP                 ; [offset]
+    PUSH1 0x20
+    ADD
+    PUSH2 loop
+    JUMP
+end:
+    JUMPDEST
+    STOP
@@ -0,0 +1,113 @@
+; LogHeavy emits events as many as requested by the calldata. It has no ABI.
+; The runtime code below is assembled with the constructor
+;   PUSH2 <runtime size> DUP1 PUSH2 13 PUSH1 0 CODECOPY PUSH1 0 RETURN
+; into the bytecode of createLogHeavyContractInfo.
+;
+; calldata: events (32 bytes) | topics (32 bytes, 0-4) | data size (32 bytes) | topic0 (32 bytes) | data
+; Every event has the given number of topics: topic0, the event index, the caller and the block number in order,
+; and the data copied from the calldata.
+
+    PUSH1 0x40
+    CALLDATALOAD        ; [size]
+    PUSH1 0x80
+    PUSH1 0x00
+    CALLDATACOPY        ; memory[0:size] = data
+    PUSH1 0x00          ; [i]
+loop:
+    JUMPDEST
+    PUSH1 0x00
+    CALLDATALOAD        ; [i, events]
+    DUP2
+    LT                  ; [i, i < events]
+    ISZERO
+    PUSH2 end
+    JUMPI
+    PUSH1 0x20
+    CALLDATALOAD        ; [i, topics]
+    DUP1
+    ISZERO
+    PUSH2 log0
+    JUMPI
+    DUP1
+    PUSH1 0x01
+    EQ
+    PUSH2 log1
+    JUMPI
+    DUP1
+    PUSH1 0x02
+    EQ
+    PUSH2 log2
+    JUMPI
+    DUP1
+    PUSH1 0x03
+    EQ
+    PUSH2 log3
+    JUMPI
+    PUSH2 log4
+    JUMP
+log4:
+    JUMPDEST
+    POP                 ; [i]
+    NUMBER
+    CALLER
+    DUP3
+    PUSH1 0x60
+    CALLDATALOAD
+    PUSH1 0x40
+    CALLDATALOAD
+    PUSH1 0x00
+    LOG4
+    PUSH2 next
+    JUMP
+log3:
+    JUMPDEST
+    POP
+    CALLER
+    DUP2
+    PUSH1 0x60
+    CALLDATALOAD
+    PUSH1 0x40
+    CALLDATALOAD
+    PUSH1 0x00
+    LOG3
+    PUSH2 next
+    JUMP
+log2:
+    JUMPDEST
+    POP
+    DUP1
+    PUSH1 0x60
+    CALLDATALOAD
+    PUSH1 0x40
+    CALLDATALOAD
+    PUSH1 0x00
+    LOG2
+    PUSH2 next
+    JUMP
+log1:
+    JUMPDEST
+    POP
+    PUSH1 0x60
+    CALLDATALOAD
+    PUSH1 0x40
+    CALLDATALOAD
+    PUSH1 0x00
+    LOG1
+    PUSH2 next
+    JUMP
+log0:
+    JUMPDEST
+    POP
+    PUSH1 0x40
+    CALLDATALOAD
+    PUSH1 0x00
+    LOG0
+next:
+    JUMPDEST            ; [i]
+    PUSH1 0x01
+    ADD
+    PUSH2 loop
+    JUMP
+end:
+    JUMPDEST
+    STOP
@@ -0,0 +1,31 @@
+; StateGrowth writes new storage slots as many as requested by the calldata. It has no ABI.
+; The runtime code below is assembled with the constructor
+;   PUSH2 <runtime size> DUP1 PUSH2 13 PUSH1 0 CODECOPY PUSH1 0 RETURN
+; into the bytecode of createStateGrowthContractInfo.
+;
+; calldata: slots (32 bytes) | base (32 bytes)
+; It stores the block number in the slots from base to base+slots-1. A random base makes every slot new.
+
+    PUSH1 0x20
+    CALLDATALOAD        ; [base]
+    PUSH1 0x00
+    CALLDATALOAD        ; [base, i]
+loop:
+    JUMPDEST
+    DUP1
+    ISZERO
+    PUSH2 end
+    JUMPI
+    PUSH1 0x01
+    SWAP1
+    SUB                 ; [base, i-1]
+    NUMBER
+    DUP3
+    DUP3
+    ADD                 ; [base, i, number, base+i]
+    SSTORE
+    PUSH2 loop
+    JUMP
+end:
+    JUMPDEST
+    STOP
@@ -0,0 +1,27 @@
+package account
+
+import "sync"
+
+// Gas limit override related variables
+var (
+	gasLimitsMu sync.RWMutex
+	gasLimits   = make(map[interface{}]uint64)
+)
+
+// SetGasLimit makes the txs sent through the client use the gas limit instead of the built-in one of every tx.
+// A TC sets it on the clients of its pool, so that the txs of the setup and of the other TCs keep their gas limits.
+func SetGasLimit(c interface{}, gasLimit uint64) {
+	gasLimitsMu.Lock()
+	defer gasLimitsMu.Unlock()
+	gasLimits[c] = gasLimit
+}
+
+// gasLimitOf returns the gas limit set on the client, or the built-in gas limit of the tx.
+func gasLimitOf(c interface{}, gasLimit uint64) uint64 {
+	gasLimitsMu.RLock()
+	defer gasLimitsMu.RUnlock()
+	if override, ok := gasLimits[c]; ok {
+		return override
+	}
+	return gasLimit
+}
@@ -0,0 +1,138 @@
+package account
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+
+	"github.com/kaiachain/kaia/accounts"
+	"github.com/kaiachain/kaia/crypto"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// HDWallet derives test account keys from a BIP-39 mnemonic or a raw BIP-32 seed.
+// Every slave derives its accounts under m/44'/8217'/{slaveIndex}'/{accList}/{index},
+// so any BIP-32 compatible tool can regenerate the same addresses afterwards.
+type HDWallet struct {
+	masterKey   []byte
+	masterChain []byte
+}
+
+// NewHDWallet creates a wallet from either a mnemonic or a hex encoded seed.
+// If both are given, the mnemonic wins.
+func NewHDWallet(mnemonic string, seedHex string) (*HDWallet, error) {
+	var seed []byte
+	if mnemonic != "" {
+		// BIP-39 seed. The mnemonic is expected to be plain ASCII (e.g. the English wordlist),
+		// so NFKD normalization is reduced to collapsing whitespace.
+		normalized := strings.Join(strings.Fields(mnemonic), " ")
+		seed = pbkdf2.Key([]byte(normalized), []byte("mnemonic"), 2048, 64, sha512.New)
+	} else if seedHex != "" {
+		var err error
+		seed, err = hex.DecodeString(strings.TrimPrefix(seedHex, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode seed: %v", err)
+		}
+	} else {
+		return nil, errors.New("either mnemonic or seed should be given")
+	}
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, fmt.Errorf("seed length should be between 16 and 64 bytes, but it is %v", len(seed))
+	}
+
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	return &HDWallet{masterKey: sum[:32], masterChain: sum[32:]}, nil
+}
+
+// AccountDerivationPath returns the derivation path of the index-th account in the accList of the given slave.
+func AccountDerivationPath(slaveIndex int, accList AccList, index int) accounts.DerivationPath {
+	return accounts.DerivationPath{
+		0x80000000 + 44,
+		0x80000000 + 8217,
+		0x80000000 + uint32(slaveIndex),
+		uint32(accList),
+		uint32(index),
+	}
+}
+
+// migratedKeyBranch is added to the accList in the derivation path of the keys which the accounts are migrated to,
+// so that they never collide with the keys of the accounts themselves.
+const migratedKeyBranch = 0x1000
+
+// MigratedKeyDerivationPath returns the derivation path of the keyIndex-th key which the index-th account in the accList of the given slave
+// is migrated to by the key type TCs.
+func MigratedKeyDerivationPath(slaveIndex int, accList AccList, index int, keyIndex int) accounts.DerivationPath {
+	return accounts.DerivationPath{
+		0x80000000 + 44,
+		0x80000000 + 8217,
+		0x80000000 + uint32(slaveIndex),
+		migratedKeyBranch + uint32(accList),
+		uint32(index),
+		uint32(keyIndex),
+	}
+}
+
+// DeriveKey derives the private key at the given path.
+func (w *HDWallet) DeriveKey(path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	key, chain := w.masterKey, w.masterChain
+	for _, childIdx := range path {
+		var err error
+		key, chain, err = deriveChildKey(key, chain, childIdx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive %v: %v", path, err)
+		}
+	}
+	return crypto.ToECDSA(key)
+}
+
+// deriveChildKey implements the BIP-32 private parent key to private child key derivation.
+func deriveChildKey(parentKey, parentChain []byte, childIdx uint32) ([]byte, []byte, error) {
+	var data []byte
+	if childIdx >= 0x80000000 {
+		data = append([]byte{0x0}, parentKey...)
+	} else {
+		parent, err := crypto.ToECDSA(parentKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		data = crypto.CompressPubkey(&parent.PublicKey)
+	}
+	data = binary.BigEndian.AppendUint32(data, childIdx)
+
+	mac := hmac.New(sha512.New, parentChain)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	n := crypto.S256().Params().N
+	il := new(big.Int).SetBytes(sum[:32])
+	if il.Cmp(n) >= 0 {
+		return nil, nil, errors.New("invalid child key")
+	}
+	childKey := il.Add(il, new(big.Int).SetBytes(parentKey))
+	childKey.Mod(childKey, n)
+	if childKey.Sign() == 0 {
+		return nil, nil, errors.New("invalid child key")
+	}
+
+	return childKey.FillBytes(make([]byte, 32)), sum[32:], nil
+}
+
+// NewHDAccount creates the index-th account in the accList of the given slave.
+func NewHDAccount(w *HDWallet, slaveIndex int, accList AccList, index int) *Account {
+	path := AccountDerivationPath(slaveIndex, accList, index)
+	key, err := w.DeriveKey(path)
+	if err != nil {
+		log.Fatalf("NewHDAccount() : Failed to derive key %v", err)
+	}
+	return GetAccountFromKey(index, hex.EncodeToString(crypto.FromECDSA(key)))
+}
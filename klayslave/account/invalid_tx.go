@@ -0,0 +1,163 @@
+package account
+
+import (
+	"crypto/ecdsa"
+	"log"
+	"math"
+	"math/big"
+
+	"github.com/kaiachain/kaia/blockchain"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/blockchain/types/accountkey"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/params"
+)
+
+// The Gen*Tx functions below return the RLP encoded txs which the node should reject, for the negative-path TCs.
+// Their senders are brand-new accounts, so no nonce of the test accounts is consumed even if the node accepts one.
+// feePayer is a test account which pays the fee of a fee delegated tx, so that the tx passes the balance check.
+
+// GenInvalidChainIDTx returns a value transfer signed for another chain.
+func GenInvalidChainIDTx() []byte {
+	from := NewAccount(0)
+	tx := types.NewTransaction(0, from.address, common.Big0, params.TxGas, gasPrice, nil)
+	signer := types.NewEIP155Signer(new(big.Int).Add(chainID, common.Big1))
+	return encodeInvalidTx(tx, signer, from.privateKey, nil)
+}
+
+// GenInvalidSignatureTx returns a value transfer of a new account signed by another key.
+func GenInvalidSignatureTx() []byte {
+	from, other := NewAccount(0), NewAccount(0)
+	tx, err := types.NewTransactionWithMap(types.TxTypeValueTransfer, map[types.TxValueKeyType]interface{}{
+		types.TxValueKeyNonce:    uint64(0),
+		types.TxValueKeyTo:       other.address,
+		types.TxValueKeyAmount:   common.Big0,
+		types.TxValueKeyGasLimit: uint64(100000),
+		types.TxValueKeyGasPrice: gasPrice,
+		types.TxValueKeyFrom:     from.address,
+	})
+	if err != nil {
+		log.Fatalf("Failed to encode tx: %v", err)
+	}
+	return encodeInvalidTx(tx, types.NewEIP155Signer(chainID), other.privateKey, nil)
+}
+
+// GenInvalidFeePayerSignatureTx returns a fee delegated value transfer whose fee payer is feePayer, but signed by another key.
+func GenInvalidFeePayerSignatureTx(feePayer *Account) []byte {
+	from, other := NewAccount(0), NewAccount(0)
+	signer := types.NewEIP155Signer(chainID)
+	tx, err := types.NewTransactionWithMap(types.TxTypeFeeDelegatedValueTransfer, map[types.TxValueKeyType]interface{}{
+		types.TxValueKeyNonce:    uint64(0),
+		types.TxValueKeyTo:       other.address,
+		types.TxValueKeyAmount:   common.Big0,
+		types.TxValueKeyGasLimit: uint64(100000),
+		types.TxValueKeyGasPrice: gasPrice,
+		types.TxValueKeyFrom:     from.address,
+		types.TxValueKeyFeePayer: feePayer.address,
+	})
+	if err != nil {
+		log.Fatalf("Failed to encode tx: %v", err)
+	}
+	return encodeInvalidTx(tx, signer, from.privateKey, other.privateKey)
+}
+
+// GenUnderpricedTx returns a value transfer whose gas price, 1 kei, is lower than the base fee.
+func GenUnderpricedTx() []byte {
+	from := NewAccount(0)
+	tx := types.NewTransaction(0, from.address, common.Big0, params.TxGas, common.Big1, nil)
+	return encodeInvalidTx(tx, types.NewEIP155Signer(chainID), from.privateKey, nil)
+}
+
+// GenOversizedTx returns a value transfer memo larger than the max tx size of the txpool.
+func GenOversizedTx() []byte {
+	from := NewAccount(0)
+	data := make([]byte, blockchain.MaxTxDataSize)
+	tx, err := types.NewTransactionWithMap(types.TxTypeValueTransferMemo, map[types.TxValueKeyType]interface{}{
+		types.TxValueKeyNonce:    uint64(0),
+		types.TxValueKeyTo:       from.address,
+		types.TxValueKeyAmount:   common.Big0,
+		types.TxValueKeyGasLimit: uint64(10000000),
+		types.TxValueKeyGasPrice: gasPrice,
+		types.TxValueKeyData:     data,
+		types.TxValueKeyFrom:     from.address,
+	})
+	if err != nil {
+		log.Fatalf("Failed to encode tx: %v", err)
+	}
+	return encodeInvalidTx(tx, types.NewEIP155Signer(chainID), from.privateKey, nil)
+}
+
+// GenOverGasLimitTx returns a fee delegated value transfer with the max gas limit.
+// Kaia has no block gas limit to check a tx against, so the node rejects it as feePayer cannot pay the fee.
+func GenOverGasLimitTx(feePayer *Account) []byte {
+	from := NewAccount(0)
+	signer := types.NewEIP155Signer(chainID)
+	tx, err := types.NewTransactionWithMap(types.TxTypeFeeDelegatedValueTransfer, map[types.TxValueKeyType]interface{}{
+		types.TxValueKeyNonce:    uint64(0),
+		types.TxValueKeyTo:       from.address,
+		types.TxValueKeyAmount:   common.Big0,
+		types.TxValueKeyGasLimit: uint64(math.MaxUint64),
+		types.TxValueKeyGasPrice: gasPrice,
+		types.TxValueKeyFrom:     from.address,
+		types.TxValueKeyFeePayer: feePayer.address,
+	})
+	if err != nil {
+		log.Fatalf("Failed to encode tx: %v", err)
+	}
+	return encodeInvalidTx(tx, signer, from.privateKey, feePayer.privateKey)
+}
+
+// GenInsufficientBalanceTx returns a value transfer of 1 kei from a new account, which has no balance.
+func GenInsufficientBalanceTx() []byte {
+	from := NewAccount(0)
+	tx := types.NewTransaction(0, from.address, common.Big1, params.TxGas, gasPrice, nil)
+	return encodeInvalidTx(tx, types.NewEIP155Signer(chainID), from.privateKey, nil)
+}
+
+// GenInvalidAccountKeyUpdateTx returns a fee delegated account update to a multisig key whose threshold exceeds the sum of the weights.
+func GenInvalidAccountKeyUpdateTx(feePayer *Account) []byte {
+	from := NewAccount(0)
+	weightedKeys := make(accountkey.WeightedPublicKeys, 2)
+	for i := range weightedKeys {
+		k, err := crypto.GenerateKey()
+		if err != nil {
+			log.Fatalf("crypto.GenerateKey() : Failed to generateKey %v", err)
+		}
+		weightedKeys[i] = accountkey.NewWeightedPublicKey(1, (*accountkey.PublicKeySerializable)(&k.PublicKey))
+	}
+	signer := types.NewEIP155Signer(chainID)
+	tx, err := types.NewTransactionWithMap(types.TxTypeFeeDelegatedAccountUpdate, map[types.TxValueKeyType]interface{}{
+		types.TxValueKeyNonce:      uint64(0),
+		types.TxValueKeyFrom:       from.address,
+		types.TxValueKeyGasLimit:   uint64(200000),
+		types.TxValueKeyGasPrice:   gasPrice,
+		types.TxValueKeyAccountKey: accountkey.NewAccountKeyWeightedMultiSigWithValues(uint(len(weightedKeys)+1), weightedKeys),
+		types.TxValueKeyFeePayer:   feePayer.address,
+	})
+	if err != nil {
+		log.Fatalf("Failed to encode tx: %v", err)
+	}
+	return encodeInvalidTx(tx, signer, from.privateKey, feePayer.privateKey)
+}
+
+// GenMalformedRLPTx returns a signed value transfer whose last byte is cut off.
+func GenMalformedRLPTx() []byte {
+	from := NewAccount(0)
+	tx := types.NewTransaction(0, from.address, common.Big0, params.TxGas, gasPrice, nil)
+	raw := encodeInvalidTx(tx, types.NewEIP155Signer(chainID), from.privateKey, nil)
+	return raw[:len(raw)-1]
+}
+
+// encodeInvalidTx signs the tx by the keys of the sender, and of the fee payer if given, and returns it RLP encoded.
+func encodeInvalidTx(tx *types.Transaction, signer types.Signer, senderKeys, feePayerKeys []*ecdsa.PrivateKey) []byte {
+	if err := tx.SignWithKeys(signer, senderKeys); err != nil {
+		log.Fatalf("Failed to sign tx: %v", err)
+	}
+	if feePayerKeys != nil {
+		if err := tx.SignFeePayerWithKeys(signer, feePayerKeys); err != nil {
+			log.Fatalf("Failed to fee payer sign tx: %v", err)
+		}
+	}
+	return toRlp(tx)
+}
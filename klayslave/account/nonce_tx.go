@@ -0,0 +1,129 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/kaiachain/kaia/blockchain"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/client"
+	"github.com/kaiachain/kaia/common"
+)
+
+// ReplacementKind is how TransferReplacementTxs replaces the pending tx.
+type ReplacementKind int
+
+const (
+	ReplaceWithGasPrice ReplacementKind = iota // a value transfer of the same nonce with a higher gas price
+	ReplaceWithCancel                          // a TxTypeCancel of the same nonce
+)
+
+// TransferNonceGapTxs sends a value transfer whose nonce is gap ahead of the next nonce, so that it sits in the queue of the txpool,
+// and fills the gap by value transfers after fillDelay. The account is locked until the gap is filled.
+// It returns the queued tx, the time actually slept before filling the gap, which is zero if the queued tx failed to be sent,
+// and the time when the gap is filled.
+func (self *Account) TransferNonceGapTxs(c *client.Client, to *Account, gap int, fillDelay time.Duration) (*types.Transaction, time.Duration, time.Time, error) {
+	ctx := context.Background()
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	nonce := self.GetNonce(c)
+
+	futureTx := self.signedValueTransferTx(nonce+uint64(gap), to, common.Big0, gasPrice)
+	if _, err := c.SendRawTransaction(ctx, futureTx); err != nil {
+		fmt.Printf("Account(%v) nonce(%v) : Failed to send the future nonce tx: %v\n", self.GetAddress().String(), futureTx.Nonce(), err)
+		return futureTx, 0, time.Time{}, err
+	}
+
+	time.Sleep(fillDelay)
+
+	for i := 0; i < gap; i++ {
+		tx := self.signedValueTransferTx(nonce+uint64(i), to, common.Big0, gasPrice)
+		if _, err := c.SendRawTransaction(ctx, tx); err != nil {
+			// The rest of the gap is filled by the next txs of the account, which replace the queued tx when they reach its nonce.
+			fmt.Printf("Account(%v) nonce(%v) : Failed to fill the nonce gap: %v\n", self.GetAddress().String(), tx.Nonce(), err)
+			self.nonce = tx.Nonce()
+			if err.Error() == blockchain.ErrNonceTooLow.Error() || err.Error() == blockchain.ErrReplaceUnderpriced.Error() {
+				self.nonce++
+			}
+			return futureTx, fillDelay, time.Time{}, err
+		}
+	}
+
+	self.nonce = futureTx.Nonce() + 1
+	return futureTx, fillDelay, time.Now(), nil
+}
+
+// TransferReplacementTxs sends a value transfer and then a tx of the same nonce which replaces it in the txpool.
+// The error of the replacement is returned as replaceErr, as the original may be already executed or the pool may refuse the replacement.
+func (self *Account) TransferReplacementTxs(c *client.Client, to *Account, value *big.Int, kind ReplacementKind, priceBumpPercent int) (original, replacement *types.Transaction, replaceErr, err error) {
+	ctx := context.Background()
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	nonce := self.GetNonce(c)
+
+	original = self.signedValueTransferTx(nonce, to, value, gasPrice)
+	switch kind {
+	case ReplaceWithGasPrice:
+		bumpedPrice := new(big.Int).Mul(gasPrice, big.NewInt(int64(100+priceBumpPercent)))
+		bumpedPrice.Div(bumpedPrice, big.NewInt(100))
+		replacement = self.signedValueTransferTx(nonce, to, value, bumpedPrice)
+	case ReplaceWithCancel:
+		replacement, err = types.NewTransactionWithMap(types.TxTypeCancel, map[types.TxValueKeyType]interface{}{
+			types.TxValueKeyNonce:    nonce,
+			types.TxValueKeyFrom:     self.address,
+			types.TxValueKeyGasLimit: uint64(100000),
+			types.TxValueKeyGasPrice: gasPrice,
+		})
+		if err != nil {
+			log.Fatalf("Failed to encode tx: %v", err)
+		}
+		if err := replacement.SignWithKeys(types.NewEIP155Signer(chainID), self.privateKey); err != nil {
+			log.Fatalf("Failed to sign tx: %v", err)
+		}
+	default:
+		log.Fatalf("Unknown replacement kind: %v", kind)
+	}
+
+	if _, err := c.SendRawTransaction(ctx, original); err != nil {
+		if err.Error() == blockchain.ErrNonceTooLow.Error() || err.Error() == blockchain.ErrReplaceUnderpriced.Error() {
+			fmt.Printf("Account(%v) nonce(%v) : Failed to sendTransaction: %v\n", self.GetAddress().String(), nonce, err)
+			fmt.Printf("Account(%v) nonce is added to %v\n", self.GetAddress().String(), nonce+1)
+			self.nonce++
+		} else {
+			fmt.Printf("Account(%v) nonce(%v) : Failed to sendTransaction: %v\n", self.GetAddress().String(), nonce, err)
+		}
+		return original, replacement, nil, err
+	}
+	self.nonce++
+
+	if _, replaceErr = c.SendRawTransaction(ctx, replacement); replaceErr != nil {
+		fmt.Printf("Account(%v) nonce(%v) : Failed to send the replacement tx: %v\n", self.GetAddress().String(), nonce, replaceErr)
+	}
+	return original, replacement, replaceErr, nil
+}
+
+// signedValueTransferTx returns a value transfer of the nonce and the gas price signed by the account.
+func (self *Account) signedValueTransferTx(nonce uint64, to *Account, value, price *big.Int) *types.Transaction {
+	tx, err := types.NewTransactionWithMap(types.TxTypeValueTransfer, map[types.TxValueKeyType]interface{}{
+		types.TxValueKeyNonce:    nonce,
+		types.TxValueKeyTo:       to.GetAddress(),
+		types.TxValueKeyAmount:   value,
+		types.TxValueKeyGasLimit: uint64(100000),
+		types.TxValueKeyGasPrice: price,
+		types.TxValueKeyFrom:     self.address,
+	})
+	if err != nil {
+		log.Fatalf("Failed to encode tx: %v", err)
+	}
+	if err := tx.SignWithKeys(types.NewEIP155Signer(chainID), self.privateKey); err != nil {
+		log.Fatalf("Failed to sign tx: %v", err)
+	}
+	return tx
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/client"
 	"github.com/kaiachain/kaia/common"
+	uniswapRouterContracts "github.com/kaiachain/kaia/contracts/contracts/libs/uniswap/router"
+	"github.com/kaiachain/kaia/params"
 )
 
 type TargetTxType struct {
@@ -30,7 +32,7 @@ var TargetTxTypeList = map[string]*TargetTxType{
 				types.TxValueKeyNonce:    nonce,
 				types.TxValueKeyTo:       account.address,
 				types.TxValueKeyAmount:   common.Big1,
-				types.TxValueKeyGasLimit: uint64(100000),
+				types.TxValueKeyGasLimit: gasLimitOf(c, uint64(100000)),
 				types.TxValueKeyGasPrice: suggestedGasPrice,
 				types.TxValueKeyFrom:     account.address,
 			})
@@ -61,7 +63,7 @@ var TargetTxTypeList = map[string]*TargetTxType{
 				types.TxValueKeyTo:       TestContractInfos[ContractCounterForTestAuction].GetAddress(c, CounterForTestAuctionDeployer),
 				types.TxValueKeyData:     TestContractInfos[ContractCounterForTestAuction].GenData(common.Address{}, common.Big1), // 1 means calling incForSC()
 				types.TxValueKeyAmount:   common.Big0,
-				types.TxValueKeyGasLimit: uint64(5000000),
+				types.TxValueKeyGasLimit: gasLimitOf(c, uint64(5000000)),
 				types.TxValueKeyGasPrice: suggestedGasPrice,
 				types.TxValueKeyFrom:     account.address,
 			})
@@ -92,7 +94,7 @@ var TargetTxTypeList = map[string]*TargetTxType{
 				types.TxValueKeyTo:       TestContractInfos[ContractCounterForTestAuction].GetAddress(c, CounterForTestAuctionDeployer),
 				types.TxValueKeyData:     TestContractInfos[ContractCounterForTestAuction].GenData(common.Address{}, common.Big2), // 2 means calling intendedRevert()
 				types.TxValueKeyAmount:   common.Big0,
-				types.TxValueKeyGasLimit: uint64(5000000),
+				types.TxValueKeyGasLimit: gasLimitOf(c, uint64(5000000)),
 				types.TxValueKeyGasPrice: suggestedGasPrice,
 				types.TxValueKeyFrom:     account.address,
 			})
@@ -294,4 +296,200 @@ var TargetTxTypeList = map[string]*TargetTxType{
 		PostSendBid: func(c *client.Client, _, tmpAccount *Account, _ uint64, suggestedGasPrice *big.Int, blockNumber *big.Int) {
 		},
 	},
+	"ERC20": {
+		Description: "ERC20 creates a SmartContractExecution that transfers 1 ERC20 token to itself.",
+		GenerateTx: func(c *client.Client, account, _ *Account, nonce uint64, suggestedGasPrice *big.Int) *types.Transaction {
+			signer := types.NewEIP155Signer(chainID)
+			tx, err := types.NewTransactionWithMap(types.TxTypeSmartContractExecution, map[types.TxValueKeyType]interface{}{
+				types.TxValueKeyNonce:    nonce,
+				types.TxValueKeyTo:       TestContractInfos[ContractErc20].GetAddress(c, ERC20Deployer),
+				types.TxValueKeyData:     TestContractInfos[ContractErc20].GenData(account.address, common.Big1),
+				types.TxValueKeyAmount:   common.Big0,
+				types.TxValueKeyGasLimit: gasLimitOf(c, uint64(100000)),
+				types.TxValueKeyGasPrice: suggestedGasPrice,
+				types.TxValueKeyFrom:     account.address,
+			})
+			if err != nil {
+				return nil
+			}
+
+			err = tx.SignWithKeys(signer, account.privateKey)
+			if err != nil {
+				return nil
+			}
+			return tx
+		},
+		PreSendBid:  noPreSendBid,
+		PostSendBid: postSendBidOneTx,
+	},
+	"FD": {
+		Description: "FD creates a FeeDelegatedValueTransfer that sends a 1kei to itself, whose fee is paid by AuctionFeePayer.",
+		GenerateTx: func(c *client.Client, account, _ *Account, nonce uint64, suggestedGasPrice *big.Int) *types.Transaction {
+			signer := types.NewEIP155Signer(chainID)
+			tx, err := types.NewTransactionWithMap(types.TxTypeFeeDelegatedValueTransfer, map[types.TxValueKeyType]interface{}{
+				types.TxValueKeyNonce:    nonce,
+				types.TxValueKeyTo:       account.address,
+				types.TxValueKeyAmount:   common.Big1,
+				types.TxValueKeyGasLimit: gasLimitOf(c, uint64(100000)),
+				types.TxValueKeyGasPrice: suggestedGasPrice,
+				types.TxValueKeyFrom:     account.address,
+				types.TxValueKeyFeePayer: AuctionFeePayer.address,
+			})
+			if err != nil {
+				return nil
+			}
+
+			err = tx.SignWithKeys(signer, account.privateKey)
+			if err != nil {
+				return nil
+			}
+			err = tx.SignFeePayerWithKeys(signer, AuctionFeePayer.privateKey)
+			if err != nil {
+				return nil
+			}
+			return tx
+		},
+		PreSendBid:  noPreSendBid,
+		PostSendBid: postSendBidOneTx,
+	},
+	"DF": {
+		Description: "DF creates an Ethereum DynamicFee tx that sends a 1kei to itself.",
+		GenerateTx: func(c *client.Client, account, _ *Account, nonce uint64, suggestedGasPrice *big.Int) *types.Transaction {
+			tx := types.NewTx(&types.TxInternalDataEthereumDynamicFee{
+				ChainID:      chainID,
+				AccountNonce: nonce,
+				Recipient:    &account.address,
+				GasLimit:     gasLimitOf(c, uint64(100000)),
+				GasFeeCap:    suggestedGasPrice,
+				GasTipCap:    suggestedGasPrice,
+				Amount:       common.Big1,
+				AccessList:   types.AccessList{},
+			})
+			err := tx.SignWithKeys(types.LatestSignerForChainID(chainID), account.privateKey)
+			if err != nil {
+				return nil
+			}
+			return tx
+		},
+		PreSendBid:  noPreSendBid,
+		PostSendBid: postSendBidOneTx,
+	},
+	"AL": {
+		Description: "AL creates an Ethereum AccessList tx that calls the counter contract, listing the counter contract and its first slot.",
+		GenerateTx: func(c *client.Client, account, _ *Account, nonce uint64, suggestedGasPrice *big.Int) *types.Transaction {
+			counter := TestContractInfos[ContractCounterForTestAuction].GetAddress(c, CounterForTestAuctionDeployer)
+			tx := types.NewTx(&types.TxInternalDataEthereumAccessList{
+				ChainID:      chainID,
+				AccountNonce: nonce,
+				Recipient:    &counter,
+				GasLimit:     gasLimitOf(c, uint64(5000000)),
+				Price:        suggestedGasPrice,
+				Amount:       common.Big0,
+				AccessList:   types.AccessList{{Address: counter, StorageKeys: []common.Hash{{}}}},
+				Payload:      TestContractInfos[ContractCounterForTestAuction].GenData(common.Address{}, common.Big1), // 1 means calling incForSC()
+			})
+			err := tx.SignWithKeys(types.LatestSignerForChainID(chainID), account.privateKey)
+			if err != nil {
+				return nil
+			}
+			return tx
+		},
+		PreSendBid:  noPreSendBid,
+		PostSendBid: postSendBidOneTx,
+	},
+	"SCD": {
+		Description: "SCD creates a SmartContractDeploy of the general purpose test contract.",
+		GenerateTx: func(c *client.Client, account, _ *Account, nonce uint64, suggestedGasPrice *big.Int) *types.Transaction {
+			signer := types.NewEIP155Signer(chainID)
+			tx, err := types.NewTransactionWithMap(types.TxTypeSmartContractDeploy, map[types.TxValueKeyType]interface{}{
+				types.TxValueKeyNonce:         nonce,
+				types.TxValueKeyFrom:          account.address,
+				types.TxValueKeyTo:            (*common.Address)(nil),
+				types.TxValueKeyAmount:        common.Big0,
+				types.TxValueKeyGasLimit:      gasLimitOf(c, uint64(1000000)),
+				types.TxValueKeyGasPrice:      suggestedGasPrice,
+				types.TxValueKeyHumanReadable: false,
+				types.TxValueKeyCodeFormat:    params.CodeFormatEVM,
+				types.TxValueKeyData:          TestContractInfos[ContractGeneral].Bytecode,
+			})
+			if err != nil {
+				return nil
+			}
+
+			err = tx.SignWithKeys(signer, account.privateKey)
+			if err != nil {
+				return nil
+			}
+			return tx
+		},
+		PreSendBid:  noPreSendBid,
+		PostSendBid: postSendBidOneTx,
+	},
+	"DEX": {
+		Description: "DEX creates a SmartContractExecution that swaps KAIA for the gasless test token via the Uniswap router.",
+		GenerateTx: func(c *client.Client, account, _ *Account, nonce uint64, suggestedGasPrice *big.Int) *types.Transaction {
+			return dexSwapTx(c, account, nonce, suggestedGasPrice, common.Big1)
+		},
+		PreSendBid:  noPreSendBid,
+		PostSendBid: postSendBidOneTx,
+	},
+	"rDEX": {
+		Description: "rDEX creates a reverted DEX swap whose minimum output cannot be met.",
+		GenerateTx: func(c *client.Client, account, _ *Account, nonce uint64, suggestedGasPrice *big.Int) *types.Transaction {
+			return dexSwapTx(c, account, nonce, suggestedGasPrice, abi.MaxUint256)
+		},
+		PreSendBid:  noPreSendBid,
+		PostSendBid: postSendBidOneTx,
+	},
+}
+
+// dexSwapAmount is the KAIA swapped by a DEX target tx, large enough to get a token out of the pool set up by SetupLiquidity.
+var dexSwapAmount = big.NewInt(1e12)
+
+// noPreSendBid is the PreSendBid of the target tx types which send nothing before the bid.
+func noPreSendBid(c *client.Client, account, _ *Account, nonce uint64, suggestedGasPrice *big.Int) error {
+	return nil
+}
+
+// postSendBidOneTx is the PostSendBid of the target tx types whose target tx is the only tx of the account.
+func postSendBidOneTx(c *client.Client, account, _ *Account, nonce uint64, suggestedGasPrice *big.Int, blockNumber *big.Int) {
+	account.nonce++
+	account.updateLastBlocknumSentTx(blockNumber.Uint64())
+}
+
+// dexSwapTx returns a swap of dexSwapAmount KAIA for the gasless test token via the Uniswap router, which reverts if it gets less than amountOutMin.
+func dexSwapTx(c *client.Client, account *Account, nonce uint64, suggestedGasPrice, amountOutMin *big.Int) *types.Transaction {
+	routerAbi, err := uniswapRouterContracts.UniswapV2Router02MetaData.GetAbi()
+	if err != nil {
+		return nil
+	}
+	path := []common.Address{
+		TestContractInfos[ContractWKaia].GetAddress(c, WKaiaDeployer),
+		TestContractInfos[ContractGaslessToken].GetAddress(c, GaslessTokenDeployer),
+	}
+	deadline := big.NewInt(time.Now().Add(1 * time.Hour).Unix())
+	data, err := routerAbi.Pack("swapExactETHForTokens", amountOutMin, path, account.address, deadline)
+	if err != nil {
+		return nil
+	}
+
+	signer := types.NewEIP155Signer(chainID)
+	tx, err := types.NewTransactionWithMap(types.TxTypeSmartContractExecution, map[types.TxValueKeyType]interface{}{
+		types.TxValueKeyNonce:    nonce,
+		types.TxValueKeyTo:       TestContractInfos[ContractUniswapV2Router].GetAddress(c, UniswapRouterDeployer),
+		types.TxValueKeyData:     data,
+		types.TxValueKeyAmount:   dexSwapAmount,
+		types.TxValueKeyGasLimit: gasLimitOf(c, uint64(500000)),
+		types.TxValueKeyGasPrice: suggestedGasPrice,
+		types.TxValueKeyFrom:     account.address,
+	})
+	if err != nil {
+		return nil
+	}
+
+	err = tx.SignWithKeys(signer, account.privateKey)
+	if err != nil {
+		return nil
+	}
+	return tx
 }
@@ -0,0 +1,340 @@
+// Package capacity searches the highest offered rate of a TC mix which the target chain sustains within the SLOs.
+package capacity
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kaiachain/kaia-load-tester/klayslave/monitor"
+	"github.com/kaiachain/kaia-load-tester/klayslave/report"
+	"github.com/myzhan/boomer"
+)
+
+const (
+	pacerInterval  = 10 * time.Millisecond
+	probeInterval  = 2 * time.Second
+	sampleInterval = 500 * time.Millisecond
+)
+
+// SLO is the condition which a step must meet to pass.
+type SLO struct {
+	MaxFailureRate      float64       // failed requests over finished requests
+	MaxInclusionLatency time.Duration // p95 of the time until a probe tx is mined
+	MaxTxPoolRatio      float64       // average txpool depth over its capacity, checked only with the txpool monitor
+	MinMinedRatio       float64       // own mined TPS over the offered rate of the write TCs
+}
+
+// Config is the configuration of the search.
+type Config struct {
+	StartRate    float64
+	MaxRate      float64
+	Resolution   float64 // the search stops when the gap between the passing and the failing rate is within this ratio
+	StepDuration time.Duration
+	WarmUp       time.Duration // the beginning of every step which is not measured
+	Cooldown     time.Duration // the idle time after a failing step to drain the txpool
+	Workers      int
+	SLO          SLO
+}
+
+// Task is a test case of the mix.
+type Task struct {
+	Name     string
+	Weight   int
+	Fn       func()
+	ReadOnly bool // set if the task sends no tx expected to be mined
+}
+
+// Probe sends a tx and returns the time until it is mined.
+type Probe func() (time.Duration, error)
+
+// Searcher drives the TC mix at the offered rate of each step and binary-searches the knee point.
+type Searcher struct {
+	cfg    Config
+	tasks  []Task
+	blocks *monitor.BlockMonitor
+	txPool *monitor.TxPoolMonitor // nil if the txpool monitor is disabled
+	probe  Probe
+
+	totalWeight int
+	writeShare  float64 // share of the write TCs in the mix
+
+	success uint64
+	failure uint64
+}
+
+// NewSearcher creates a searcher. The longest window of the block monitor must cover the measured part of a step.
+func NewSearcher(cfg Config, tasks []Task, blocks *monitor.BlockMonitor, txPool *monitor.TxPoolMonitor, probe Probe) (*Searcher, error) {
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("no task to run")
+	}
+	if cfg.StartRate <= 0 || cfg.MaxRate < cfg.StartRate {
+		return nil, fmt.Errorf("invalid rate range: start=%v max=%v", cfg.StartRate, cfg.MaxRate)
+	}
+	if cfg.Resolution <= 0 || cfg.Resolution >= 1 {
+		return nil, fmt.Errorf("resolution should be in (0, 1): %v", cfg.Resolution)
+	}
+	if cfg.WarmUp >= cfg.StepDuration {
+		return nil, fmt.Errorf("warm-up (%v) should be shorter than the step duration (%v)", cfg.WarmUp, cfg.StepDuration)
+	}
+	if cfg.Workers <= 0 {
+		return nil, fmt.Errorf("workers should be positive: %d", cfg.Workers)
+	}
+
+	s := &Searcher{cfg: cfg, tasks: tasks, blocks: blocks, txPool: txPool, probe: probe}
+	writeWeight := 0
+	for _, task := range tasks {
+		s.totalWeight += task.Weight
+		if !task.ReadOnly {
+			writeWeight += task.Weight
+		}
+	}
+	if s.totalWeight <= 0 {
+		return nil, fmt.Errorf("the total weight of the tasks should be positive")
+	}
+	s.writeShare = float64(writeWeight) / float64(s.totalWeight)
+	return s, nil
+}
+
+// Run ramps the rate up by doubling it until a step violates the SLOs, then binary-searches between the last passing
+// and the first failing rate. It returns the highest passing rate as the knee point.
+// If the start rate already violates the SLOs, the search stops there and the result is marked BelowStartRate.
+func (s *Searcher) Run() report.CapacityResult {
+	if err := boomer.Events.Subscribe("request_success", s.onSuccess); err != nil {
+		log.Printf("Capacity: failed to subscribe request_success: %v", err)
+	}
+	if err := boomer.Events.Subscribe("request_failure", s.onFailure); err != nil {
+		log.Printf("Capacity: failed to subscribe request_failure: %v", err)
+	}
+
+	result := report.CapacityResult{SLO: s.sloStrings()}
+	var lo, hi float64 // the highest passing rate and the lowest failing rate
+	knee := -1         // index of the step at the knee point
+
+	step := func(rate float64) bool {
+		res := s.runStep(rate)
+		result.Steps = append(result.Steps, res)
+		if res.Pass {
+			lo = rate
+			knee = len(result.Steps) - 1
+		} else {
+			hi = rate
+			time.Sleep(s.cfg.Cooldown)
+		}
+		return res.Pass
+	}
+
+	for rate := s.cfg.StartRate; ; rate *= 2 {
+		if rate > s.cfg.MaxRate {
+			rate = s.cfg.MaxRate
+		}
+		if !step(rate) || rate == s.cfg.MaxRate {
+			break
+		}
+	}
+	if lo == 0 {
+		// There is no passing rate to search from, so the knee point is somewhere below the start rate.
+		result.BelowStartRate = true
+		log.Printf("Capacity: even the start rate %.1f requests/s violated the SLOs, the knee point is below it", s.cfg.StartRate)
+		return result
+	}
+	for hi > 0 && (hi-lo)/hi > s.cfg.Resolution {
+		step((lo + hi) / 2)
+	}
+
+	result.Saturated = hi > 0
+	result.KneeRate = lo
+	if knee >= 0 {
+		result.KneeMinedTPS = result.Steps[knee].MinedTPS
+	}
+	if !result.Saturated {
+		log.Printf("Capacity: the max rate %.1f requests/s met the SLOs, the knee point may be higher", lo)
+	} else {
+		log.Printf("Capacity: knee point is %.1f requests/s (mined TPS %.1f), %.1f requests/s violated the SLOs", lo, result.KneeMinedTPS, hi)
+	}
+	return result
+}
+
+// runStep offers the rate for the step duration and measures the part after the warm-up.
+func (s *Searcher) runStep(rate float64) report.CapacityStep {
+	log.Printf("Capacity: offering %.1f requests/s for %v", rate, s.cfg.StepDuration)
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	tokens := make(chan struct{}, s.cfg.Workers)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.pace(rate, tokens, stop)
+	}()
+	for i := 0; i < s.cfg.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				case <-tokens:
+					s.pick().Fn()
+				}
+			}
+		}()
+	}
+
+	time.Sleep(s.cfg.WarmUp)
+	measured := s.cfg.StepDuration - s.cfg.WarmUp
+	success, failure := atomic.LoadUint64(&s.success), atomic.LoadUint64(&s.failure)
+	latencies, txPoolRatios := s.sample(measured)
+	success, failure = atomic.LoadUint64(&s.success)-success, atomic.LoadUint64(&s.failure)-failure
+	mined := s.blocks.Stats(measured)
+
+	close(stop)
+	wg.Wait()
+
+	res := report.CapacityStep{
+		OfferedRate:  rate,
+		AchievedRate: float64(success+failure) / measured.Seconds(),
+		MinedTPS:     mined.TPS,
+		OwnMinedTPS:  mined.TPS * mined.OwnTxShare,
+	}
+	if success+failure > 0 {
+		res.FailureRate = float64(failure) / float64(success+failure)
+	}
+	for _, ratio := range txPoolRatios {
+		res.TxPoolRatio += ratio / float64(len(txPoolRatios))
+	}
+
+	slo := s.cfg.SLO
+	if res.FailureRate > slo.MaxFailureRate {
+		res.Violations = append(res.Violations, fmt.Sprintf("failure rate %.4f > %.4f", res.FailureRate, slo.MaxFailureRate))
+	}
+	if len(latencies) == 0 {
+		res.Violations = append(res.Violations, "no probe tx was mined")
+	} else {
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		p95 := latencies[(len(latencies)*95-1)/100]
+		res.InclusionLatencyMs = p95.Milliseconds()
+		if p95 > slo.MaxInclusionLatency {
+			res.Violations = append(res.Violations, fmt.Sprintf("inclusion latency p95 %v > %v", p95, slo.MaxInclusionLatency))
+		}
+	}
+	if s.txPool != nil && res.TxPoolRatio > slo.MaxTxPoolRatio {
+		res.Violations = append(res.Violations, fmt.Sprintf("txpool ratio %.2f > %.2f", res.TxPoolRatio, slo.MaxTxPoolRatio))
+	}
+	// A mix of read TCs only sends no tx, so the mined ratio is not checked.
+	if s.writeShare > 0 {
+		expected := rate * s.writeShare
+		if ratio := res.OwnMinedTPS / expected; ratio < slo.MinMinedRatio {
+			res.Violations = append(res.Violations, fmt.Sprintf("mined ratio %.2f (own mined TPS %.1f / offered write rate %.1f) < %.2f", ratio, res.OwnMinedTPS, expected, slo.MinMinedRatio))
+		}
+	}
+	res.Pass = len(res.Violations) == 0
+
+	log.Printf("Capacity: offered %.1f, achieved %.1f requests/s, mined TPS %.1f (own %.1f), failure rate %.4f, inclusion p95 %dms, txpool ratio %.2f, pass=%v %v",
+		res.OfferedRate, res.AchievedRate, res.MinedTPS, res.OwnMinedTPS, res.FailureRate, res.InclusionLatencyMs, res.TxPoolRatio, res.Pass, res.Violations)
+	return res
+}
+
+// pace issues tokens at the rate until stop is closed. Tokens are dropped when every worker is busy, so the achieved
+// rate falls below the offered one once the endpoint cannot keep up.
+func (s *Searcher) pace(rate float64, tokens chan<- struct{}, stop <-chan struct{}) {
+	ticker := time.NewTicker(pacerInterval)
+	defer ticker.Stop()
+	last := time.Now()
+	credit := 0.0
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			credit += rate * now.Sub(last).Seconds()
+			last = now
+			for ; credit >= 1; credit-- {
+				select {
+				case tokens <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}
+}
+
+// sample sends the probe txs and samples the txpool depth for the duration.
+func (s *Searcher) sample(duration time.Duration) ([]time.Duration, []float64) {
+	var (
+		mu        sync.Mutex
+		latencies []time.Duration
+		ratios    []float64
+		wg        sync.WaitGroup
+	)
+	deadline := time.Now().Add(duration)
+
+	probeTicker := time.NewTicker(probeInterval)
+	defer probeTicker.Stop()
+	sampleTicker := time.NewTicker(sampleInterval)
+	defer sampleTicker.Stop()
+	for time.Now().Before(deadline) {
+		select {
+		case <-probeTicker.C:
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				latency, err := s.probe()
+				if err != nil {
+					log.Printf("Capacity: probe tx failed: %v", err)
+					return
+				}
+				mu.Lock()
+				latencies = append(latencies, latency)
+				mu.Unlock()
+			}()
+		case <-sampleTicker.C:
+			if s.txPool != nil {
+				ratios = append(ratios, s.txPool.Ratio())
+			}
+		}
+	}
+	wg.Wait()
+	return latencies, ratios
+}
+
+// pick chooses a task randomly in proportion to the weights.
+func (s *Searcher) pick() Task {
+	n := rand.Intn(s.totalWeight)
+	for _, task := range s.tasks {
+		if n < task.Weight {
+			return task
+		}
+		n -= task.Weight
+	}
+	return s.tasks[len(s.tasks)-1]
+}
+
+func (s *Searcher) sloStrings() map[string]string {
+	slo := map[string]string{
+		"maxFailureRate":      fmt.Sprint(s.cfg.SLO.MaxFailureRate),
+		"maxInclusionLatency": s.cfg.SLO.MaxInclusionLatency.String(),
+		"minMinedRatio":       fmt.Sprint(s.cfg.SLO.MinMinedRatio),
+	}
+	if s.txPool != nil {
+		slo["maxTxPoolRatio"] = fmt.Sprint(s.cfg.SLO.MaxTxPoolRatio)
+	}
+	return slo
+}
+
+func (s *Searcher) onSuccess(requestType string, name string, responseTime interface{}, responseLength int64) {
+	if requestType != report.OnChainRequestType && requestType != report.VerifyRequestType {
+		atomic.AddUint64(&s.success, 1)
+	}
+}
+
+func (s *Searcher) onFailure(requestType string, name string, responseTime interface{}, exception string) {
+	if requestType != report.OnChainRequestType && requestType != report.VerifyRequestType {
+		atomic.AddUint64(&s.failure, 1)
+	}
+}
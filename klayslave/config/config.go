@@ -7,14 +7,17 @@ import (
 	"math/big"
 	"net/http"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/kaiachain/kaia-load-tester/klayslave/account"
+	"github.com/kaiachain/kaia-load-tester/klayslave/capacity"
 	"github.com/kaiachain/kaia-load-tester/testcase"
 	klay "github.com/kaiachain/kaia/client"
+	"github.com/kaiachain/kaia/networks/rpc"
 	"github.com/kaiachain/kaia/params"
 	"github.com/urfave/cli"
 )
@@ -27,6 +30,9 @@ type Config struct {
 	activeUserPercent   int
 
 	richWalletPrivateKey string
+	mnemonic             string
+	seed                 string
+	slaveIndex           int
 	tcNameList           []string
 	tcWeights            []int
 
@@ -35,12 +41,37 @@ type Config struct {
 	chargeKLAYAmount  int
 	chargeParallelNum int
 
+	multiSigThreshold int
+	multiSigNumKeys   int
+	roleKeyType       string
+
+	newAccountKeyType string
+	newAccountKLAY    int
+	reuseNewAccounts  bool
+
 	gEndpoint string
+	reportDir string
+
+	blockMonitor        bool
+	blockMonitorWindows []time.Duration
+	verifyRatio         float64
+	tcParams            map[string]map[string]string
+
+	txPoolMonitor       bool
+	txPoolBackpressure  bool
+	txPoolInspect       bool
+	txPoolCapacity      int
+	txPoolLowWatermark  float64
+	txPoolHighWatermark float64
+
+	// All flag values for the end-of-run report. Secrets are masked.
+	flagValues map[string]string
 
 	// Directly from connected node
-	gasPrice *big.Int
-	chainID  *big.Int
-	baseFee  *big.Int
+	gasPrice    *big.Int
+	chainID     *big.Int
+	baseFee     *big.Int
+	nodeVersion string
 
 	// Additionally generated by this test code
 	gCli *klay.Client
@@ -77,11 +108,42 @@ func (cfg *Config) setConfigsFromFlag(ctx *cli.Context) {
 	cfg.gEndpoint = ctx.String("endpoint")
 	cfg.nUserForSigned = ctx.Int("vusigned")
 	cfg.nUserForUnsigned = ctx.Int("vuunsigned")
-	cfg.nUserForNewAccounts = 5
+	cfg.nUserForNewAccounts = ctx.Int("vunewaccounts")
 	cfg.activeUserPercent = ctx.Int("activeUserPercent")
 	cfg.chargeKLAYAmount = ctx.Int("charge")
 	cfg.chargeParallelNum = ctx.Int("chargeParallel")
 	cfg.richWalletPrivateKey = ctx.String("key")
+	cfg.mnemonic = ctx.String("mnemonic")
+	cfg.seed = ctx.String("seed")
+	cfg.slaveIndex = ctx.Int("slaveIndex")
+	cfg.multiSigThreshold = ctx.Int("multisigThreshold")
+	cfg.multiSigNumKeys = ctx.Int("multisigKeys")
+	cfg.roleKeyType = ctx.String("roleKeyType")
+	cfg.newAccountKeyType = ctx.String("newAccountKeyType")
+	cfg.newAccountKLAY = ctx.Int("newAccountKLAY")
+	cfg.reuseNewAccounts = ctx.Bool("reuseNewAccounts")
+	cfg.reportDir = ctx.String("reportDir")
+	cfg.blockMonitor = ctx.Bool("blockMonitor")
+	cfg.txPoolMonitor = ctx.Bool("txpoolMonitor")
+	cfg.txPoolBackpressure = ctx.Bool("txpoolBackpressure")
+	cfg.txPoolInspect = ctx.Bool("txpoolInspect")
+	cfg.txPoolCapacity = ctx.Int("txpoolCapacity")
+	cfg.txPoolLowWatermark = ctx.Float64("txpoolLowWatermark")
+	cfg.txPoolHighWatermark = ctx.Float64("txpoolHighWatermark")
+	cfg.verifyRatio = ctx.Float64("verify-ratio")
+	cfg.flagValues = make(map[string]string)
+	// Subcommands such as capacity have their own flags, which FlagNames returns
+	for _, name := range append(ctx.GlobalFlagNames(), ctx.FlagNames()...) {
+		generic := ctx.Generic(name)
+		if generic == nil {
+			continue // an app flag which the subcommand does not have
+		}
+		value := fmt.Sprint(generic)
+		if (name == "key" || name == "mnemonic" || name == "seed") && value != "" {
+			value = "<masked>"
+		}
+		cfg.flagValues[name] = value
+	}
 
 	// Do not allow null richWalletPrivateKey
 	if cfg.richWalletPrivateKey == "" {
@@ -91,31 +153,90 @@ func (cfg *Config) setConfigsFromFlag(ctx *cli.Context) {
 	if cfg.activeUserPercent > 100 || cfg.activeUserPercent <= 0 {
 		log.Fatalf("ActiveAccountPercent should be between 0 and 100, but it is %v", cfg.activeUserPercent)
 	}
-	// Parse tcNames
-	tcNames := ctx.String("tc")
-	for _, name := range strings.Split(tcNames, ",") {
-		// skip unknown tc
-		if _, ok := testcase.TcList[name]; !ok {
-			continue
+	// Do not allow the negative slaveIndex since it is used as a hardened derivation index
+	if cfg.slaveIndex < 0 {
+		log.Fatalf("slaveIndex should not be negative, but it is %v", cfg.slaveIndex)
+	}
+	// Do not allow the negative multisigThreshold since it is converted to uint
+	if cfg.multiSigThreshold < 0 {
+		log.Fatalf("multisigThreshold should not be negative, but it is %v", cfg.multiSigThreshold)
+	}
+	// Do not allow the unknown newAccountKeyType
+	switch account.AccountKeyType(cfg.newAccountKeyType) {
+	case account.AccountKeyTypeLegacy, account.AccountKeyTypePublic, account.AccountKeyTypeMultiSig, account.AccountKeyTypeRoleBased:
+	default:
+		log.Fatalf("newAccountKeyType should be one of legacy, public, multisig and roleBased, but it is %v", cfg.newAccountKeyType)
+	}
+	// Do not allow the watermarks which cannot throttle
+	if cfg.txPoolCapacity <= 0 {
+		log.Fatalf("txpoolCapacity should be positive, but it is %v", cfg.txPoolCapacity)
+	}
+	if cfg.txPoolLowWatermark < 0 || cfg.txPoolLowWatermark >= cfg.txPoolHighWatermark {
+		log.Fatalf("txpoolLowWatermark(%v) should be between 0 and txpoolHighWatermark(%v)", cfg.txPoolLowWatermark, cfg.txPoolHighWatermark)
+	}
+	if cfg.verifyRatio < 0 || cfg.verifyRatio > 1 {
+		log.Fatalf("verify-ratio(%v) should be between 0 and 1", cfg.verifyRatio)
+	}
+	// Parse the scenario file, which sets the TCs, their weights and parameters instead of --tc and --weights
+	cfg.tcParams = make(map[string]map[string]string)
+	scenarioFile := ctx.String("scenario")
+	if scenarioFile != "" {
+		if ctx.String("tc") != "" || ctx.String("weights") != "" {
+			log.Fatal("scenario cannot be used with tc and weights. Set the TCs and their weights in the scenario file.")
+		}
+		cfg.loadScenario(scenarioFile)
+	}
+	// Parse the per-TC parameters given as <tc>.<name>=<value>, which override those of the scenario file
+	for _, str := range ctx.StringSlice("tc-param") {
+		key, value, ok := strings.Cut(str, "=")
+		tcName, name, ok2 := strings.Cut(key, ".")
+		if !ok || !ok2 {
+			log.Fatalf("tc-param should be <tc>.<name>=<value>: %v", str)
+		}
+		if err := testcase.ValidateTCParam(tcName, name, value); err != nil {
+			log.Fatalf("Invalid tc-param %v: %v", str, err)
+		}
+		if cfg.tcParams[tcName] == nil {
+			cfg.tcParams[tcName] = make(map[string]string)
+		}
+		cfg.tcParams[tcName][name] = value
+	}
+	// Parse blockMonitorWindows
+	for _, sWindow := range strings.Split(ctx.String("blockMonitorWindows"), ",") {
+		window, err := time.ParseDuration(strings.TrimSpace(sWindow))
+		if err != nil || window <= 0 {
+			log.Fatalf("Failed to parse blockMonitorWindows: %v", sWindow)
 		}
-		// add known tc
-		cfg.tcNameList = append(cfg.tcNameList, name)
+		cfg.blockMonitorWindows = append(cfg.blockMonitorWindows, window)
 	}
+	sort.Slice(cfg.blockMonitorWindows, func(i, j int) bool { return cfg.blockMonitorWindows[i] < cfg.blockMonitorWindows[j] })
 
-	// Parse tcWeights
-	tcWeights := ctx.String("weights")
-	for _, sWeight := range strings.Split(tcWeights, ",") {
-		iWeight, err := strconv.Atoi(sWeight)
-		if err != nil {
-			cfg.tcWeights = []int{}
-			fmt.Printf("Default weight will be used. (Failed to parse weights: %v: %s)\n", err, sWeight)
-			break
+	// Parse tcNames and tcWeights unless the scenario file sets them
+	tcNames := ctx.String("tc")
+	if scenarioFile == "" {
+		for _, name := range strings.Split(tcNames, ",") {
+			// skip unknown tc
+			if _, ok := testcase.TcList[name]; !ok {
+				continue
+			}
+			// add known tc
+			cfg.tcNameList = append(cfg.tcNameList, name)
+		}
+
+		tcWeights := ctx.String("weights")
+		for _, sWeight := range strings.Split(tcWeights, ",") {
+			iWeight, err := strconv.Atoi(sWeight)
+			if err != nil {
+				cfg.tcWeights = []int{}
+				fmt.Printf("Default weight will be used. (Failed to parse weights: %v: %s)\n", err, sWeight)
+				break
+			}
+			cfg.tcWeights = append(cfg.tcWeights, iWeight)
 		}
-		cfg.tcWeights = append(cfg.tcWeights, iWeight)
 	}
 
-	// Parse auctionTargetTxTypeList when auctionBidTC or auctionRevertedBidTC is set
-	if cfg.InTheTcList("auctionBidTC") || cfg.InTheTcList("auctionRevertedBidTC") {
+	// Parse auctionTargetTxTypeList when an auction TC is set
+	if cfg.InTheTcList("auctionBidTC") || cfg.InTheTcList("auctionRevertedBidTC") || cfg.InTheTcList("auctionCompetitiveBidTC") {
 		auctionTargetTxTypeList := ctx.String("auctionTargetTxTypeList")
 		if len(auctionTargetTxTypeList) == 0 {
 			log.Fatal("auctionTargetTxTypeList is not set. Please set auctionTargetTxTypeList.")
@@ -123,10 +244,14 @@ func (cfg *Config) setConfigsFromFlag(ctx *cli.Context) {
 		for _, sType := range strings.Split(auctionTargetTxTypeList, ",") {
 			// skip unknown targetTxType
 			if _, ok := account.TargetTxTypeList[sType]; !ok {
+				fmt.Printf("Unknown auction target tx type is skipped: %v\n", sType)
 				continue
 			}
 			cfg.auctionTargetTxTypeList = append(cfg.auctionTargetTxTypeList, sType)
 		}
+		if len(cfg.auctionTargetTxTypeList) == 0 {
+			log.Fatal("No valid auction target tx type is set. Input auctionTargetTxTypeList was '" + auctionTargetTxTypeList + "'")
+		}
 	}
 
 	if len(cfg.tcWeights) != 0 && len(cfg.tcWeights) != len(cfg.tcNameList) {
@@ -138,16 +263,18 @@ func (cfg *Config) setConfigsFromFlag(ctx *cli.Context) {
 	}
 
 	maxRPC := ctx.Int("max-rps")
-	if (cfg.InTheTcList("auctionBidTC") || cfg.InTheTcList("auctionRevertedBidTC")) && cfg.nUserForSigned < maxRPC {
-		log.Fatal("When auctionBidTC or auctionRevertedBidTC is set, nUserForSigned must be larger than max-rps")
+	if (cfg.InTheTcList("auctionBidTC") || cfg.InTheTcList("auctionRevertedBidTC") || cfg.InTheTcList("auctionCompetitiveBidTC")) && cfg.nUserForSigned < maxRPC {
+		log.Fatal("When an auction TC is set, nUserForSigned must be larger than max-rps")
 	}
 
 	fmt.Println("Arguments are set like the following:")
 	fmt.Printf("- Target EndPoint = %v\n", cfg.gEndpoint)
 	fmt.Printf("- nUserForSigned = %v\n", cfg.nUserForSigned)
 	fmt.Printf("- nUserForUnsigned = %v\n", cfg.nUserForUnsigned)
+	fmt.Printf("- nUserForNewAccounts = %v\n", cfg.nUserForNewAccounts)
 	fmt.Printf("- activeUserPercent = %v\n", cfg.activeUserPercent)
 	fmt.Printf("- coinbasePrivatekey = %v\n", cfg.richWalletPrivateKey)
+	fmt.Printf("- deterministic accounts = %v (slaveIndex = %v)\n", cfg.UseHDAccounts(), cfg.slaveIndex)
 	fmt.Printf("- charging KLAY Amount = %v\n", cfg.chargeKLAYAmount)
 	fmt.Printf("- chargeParallel = %v\n", cfg.chargeParallelNum)
 	fmt.Printf("- tc = %v\n", cfg.tcNameList)
@@ -184,6 +311,16 @@ func (cfg *Config) setConfigsFromNode() {
 		time.Sleep(2 * time.Second)
 	}
 
+	// update node version. It is only used for the report, so failure is not fatal.
+	if rpcCli, err := rpc.Dial(cfg.gEndpoint); err != nil {
+		log.Printf("Failed to connect to %v for the node version: %v", cfg.gEndpoint, err)
+	} else {
+		if err := rpcCli.CallContext(context.Background(), &cfg.nodeVersion, "kaia_clientVersion"); err != nil {
+			log.Printf("Failed to get the node version: %v", err)
+		}
+		rpcCli.Close()
+	}
+
 	// update
 	// TODO: Uncomment below when klaytn 1.8.0 is released.
 	//for {
@@ -209,7 +346,7 @@ func (cfg *Config) GetExtendedTasks() []*testcase.ExtendedTask {
 			if len(cfg.tcWeights) > i {
 				weight = cfg.tcWeights[i]
 			}
-			tasks = append(tasks, &testcase.ExtendedTask{Name: task.Name, Weight: weight, Init: task.Init, Run: task.Run, TestContracts: task.TestContracts})
+			tasks = append(tasks, &testcase.ExtendedTask{Name: task.Name, Weight: weight, Init: task.Init, Run: task.Run, TestContracts: task.TestContracts, ReadOnly: task.ReadOnly, NoBackpressure: task.NoBackpressure})
 		}
 	}
 	return tasks
@@ -228,6 +365,39 @@ func (cfg *Config) GetAuctionTargetTxTypeList() []string { return cfg.auctionTar
 func (cfg *Config) GetRichWalletPrivateKey() string      { return cfg.richWalletPrivateKey }
 func (cfg *Config) GetGCli() *klay.Client                { return cfg.gCli }
 func (cfg *Config) GetChargeParallelNum() int            { return cfg.chargeParallelNum }
+func (cfg *Config) GetMnemonic() string                  { return cfg.mnemonic }
+func (cfg *Config) GetSeed() string                      { return cfg.seed }
+func (cfg *Config) GetSlaveIndex() int                   { return cfg.slaveIndex }
+func (cfg *Config) GetMultiSigThreshold() uint           { return uint(cfg.multiSigThreshold) }
+func (cfg *Config) GetMultiSigNumKeys() int              { return cfg.multiSigNumKeys }
+func (cfg *Config) GetRoleKeyType() account.AccountKeyType {
+	return account.AccountKeyType(cfg.roleKeyType)
+}
+func (cfg *Config) GetNewAccountKeyType() account.AccountKeyType {
+	return account.AccountKeyType(cfg.newAccountKeyType)
+}
+func (cfg *Config) GetNewAccountValue() *big.Int {
+	return new(big.Int).Mul(big.NewInt(int64(cfg.newAccountKLAY)), big.NewInt(params.KAIA))
+}
+func (cfg *Config) GetReuseNewAccounts() bool               { return cfg.reuseNewAccounts }
+func (cfg *Config) GetReportDir() string                    { return cfg.reportDir }
+func (cfg *Config) GetFlagValues() map[string]string        { return cfg.flagValues }
+func (cfg *Config) GetNodeVersion() string                  { return cfg.nodeVersion }
+func (cfg *Config) GetBlockMonitor() bool                   { return cfg.blockMonitor }
+func (cfg *Config) GetBlockMonitorWindows() []time.Duration { return cfg.blockMonitorWindows }
+func (cfg *Config) GetVerifyRatio() float64                 { return cfg.verifyRatio }
+func (cfg *Config) GetTxPoolMonitor() bool                  { return cfg.txPoolMonitor || cfg.txPoolBackpressure }
+func (cfg *Config) GetTxPoolBackpressure() bool             { return cfg.txPoolBackpressure }
+func (cfg *Config) GetTxPoolInspect() bool                  { return cfg.txPoolInspect }
+func (cfg *Config) GetTxPoolCapacity() int                  { return cfg.txPoolCapacity }
+func (cfg *Config) GetTCParams() map[string]map[string]string {
+	return cfg.tcParams
+}
+func (cfg *Config) GetTxPoolWatermarks() (float64, float64) {
+	return cfg.txPoolLowWatermark, cfg.txPoolHighWatermark
+}
+func (cfg *Config) GetTcWeights() []int { return cfg.tcWeights }
+func (cfg *Config) UseHDAccounts() bool { return cfg.mnemonic != "" || cfg.seed != "" }
 func (cfg *Config) InTheTcList(tcName string) bool {
 	for _, tc := range cfg.tcNameList {
 		if tcName == tc {
@@ -250,7 +420,7 @@ func (cfg *Config) GetChargeValue() *big.Int {
 	return new(big.Int).Mul(big.NewInt(int64(cfg.chargeKLAYAmount)), big.NewInt(params.KAIA))
 }
 func (cfg *Config) GetTotalChargeValue() *big.Int {
-	return new(big.Int).Mul(cfg.GetChargeValue(), big.NewInt(int64(cfg.nUserForUnsigned+cfg.nUserForSigned+cfg.nUserForNewAccounts+int(account.ContractEnd))))
+	return new(big.Int).Mul(cfg.GetChargeValue(), big.NewInt(int64(cfg.nUserForUnsigned+cfg.nUserForSigned+int(account.ContractEnd))))
 }
 
 // Flags TODO-kaia-load-tester: add env.var
@@ -258,12 +428,33 @@ var Flags = []cli.Flag{
 	cli.StringFlag{Name: "endpoint", Value: "http://localhost:8551", Usage: "Target EndPoint"},
 	cli.IntFlag{Name: "vusigned", Value: 5, Usage: "num of test account for signed Tx TC"},
 	cli.IntFlag{Name: "vuunsigned", Value: 5, Usage: "num of test account for unsigned Tx TC"},
-	//cli.IntFlag{Name: "acc.nUserForNewAccounts", Value: 5, Usage: "num of new accounts"}, // TODO-kaia-load-tester: find out what this value for
+	cli.IntFlag{Name: "vunewaccounts", Value: 5, Usage: "num of test account which creates new accounts in newAccountCreationTC"},
 	cli.IntFlag{Name: "activeUserPercent", Value: 100, Usage: "percent of active accounts"},
 	cli.IntFlag{Name: "charge", Value: 1000000000, Usage: "charging amount for each test account in KLAY"},
 	cli.IntFlag{Name: "chargeParallel", Value: 0, Usage: "number of parallel transactions for charging accounts (0 = auto-detect based on CPU cores)"},
 	cli.IntFlag{Name: "maxidleconns", Value: 100, Usage: "maximum number of idle connections in default http client"},
 	cli.StringFlag{Name: "key", Usage: "private key of rich account for kaia charging of test accounts"},
+	cli.StringFlag{Name: "mnemonic", Value: "", Usage: "BIP-39 mnemonic to derive test accounts from. If neither mnemonic nor seed is set, random accounts are used."},
+	cli.StringFlag{Name: "seed", Value: "", Usage: "hex encoded BIP-32 seed to derive test accounts from. Ignored if mnemonic is set."},
+	cli.IntFlag{Name: "slaveIndex", Value: 0, Usage: "index of this slave. Test accounts are derived at m/44'/8217'/{slaveIndex}'/{accList}/{index}."},
+	cli.IntFlag{Name: "multisigThreshold", Value: 2, Usage: "threshold of the AccountKeyWeightedMultiSig used by multisig and roleBased key type TCs. Every key has weight 1."},
+	cli.IntFlag{Name: "multisigKeys", Value: 3, Usage: "number of keys of the AccountKeyWeightedMultiSig used by multisig and roleBased key type TCs (max 10)"},
+	cli.StringFlag{Name: "roleKeyType", Value: "public", Usage: "key type of each role of the AccountKeyRoleBased used by roleBased key type TCs (public or multisig)"},
+	cli.StringFlag{Name: "newAccountKeyType", Value: "legacy", Usage: "key type of the accounts created by newAccountCreationTC (legacy, public, multisig or roleBased)"},
+	cli.IntFlag{Name: "newAccountKLAY", Value: 10, Usage: "initial balance of each account created by newAccountCreationTC in KLAY. It should cover the fee of the account update for non-legacy key types."},
+	cli.BoolFlag{Name: "reuseNewAccounts", Usage: "add the accounts created by newAccountCreationTC to the account set of createdAccountValueTransferTC"},
+	cli.StringFlag{Name: "reportDir", Value: "report", Usage: "directory where the JSON and HTML report is written at shutdown. Set empty to disable the report."},
+	cli.BoolFlag{Name: "blockMonitor", Usage: "follow new blocks and report on-chain TPS, gas/s, block time, base fee and the share of own txs. The capacity command always runs it."},
+	cli.StringFlag{Name: "blockMonitorWindows", Value: "10s,60s", Usage: "sliding windows of the block monitor, separated by comma"},
+	cli.BoolFlag{Name: "txpoolMonitor", Usage: "poll txpool_status of the endpoint and report the pool depth. It needs the txpool RPC namespace, and is also enabled by txpoolBackpressure."},
+	cli.BoolFlag{Name: "txpoolBackpressure", Usage: "slow down write TCs when the txpool nears capacity and pause them at the high watermark"},
+	cli.BoolFlag{Name: "txpoolInspect", Usage: "count the txs of the test accounts in the txpool with txpool_inspect"},
+	cli.IntFlag{Name: "txpoolCapacity", Value: 5120, Usage: "capacity of the txpool of the endpoint, i.e. txpool.exec-slots.all + txpool.nonexec-slots.all"},
+	cli.Float64Flag{Name: "txpoolLowWatermark", Value: 0.7, Usage: "ratio of the txpool depth to the capacity from which write TCs are slowed down"},
+	cli.Float64Flag{Name: "txpoolHighWatermark", Value: 0.9, Usage: "ratio of the txpool depth to the capacity at which write TCs are paused"},
+	cli.Float64Flag{Name: "verify-ratio", Value: 0, Usage: "share of the sent txs whose receipts are verified in background (0 to 1). 0 disables the verification."},
+	cli.StringSliceFlag{Name: "tc-param", Usage: "parameter of a TC as <tc>.<name>=<value>, e.g. cpuHeavyTC.size=500. It can be repeated, and overrides the scenario file. Run list-tcs to see the parameters"},
+	cli.StringFlag{Name: "scenario", Value: "", Usage: "JSON file of the TCs to run with their weights and parameters, instead of tc and weights. See README for the format"},
 	cli.StringFlag{Name: "tc", Value: "", Usage: "tasks which user want to run, multiple tasks are separated by comma."},
 	cli.StringFlag{Name: "weights", Value: "", Usage: "weights which user want to run, multiple weights are separated by comma."},
 	cli.StringFlag{Name: "auctionTargetTxTypeList", Value: "", Usage: "auction target tx types which user want to run, multiple target tx types are separated by comma."},
@@ -271,6 +462,40 @@ var Flags = []cli.Flag{
 	cli.StringFlag{Name: "gsrAddr", Value: "", Usage: "Address of Gasless Swap Router"},
 }
 
+// CapacityFlags are the flags of the capacity subcommand in addition to Flags.
+var CapacityFlags = []cli.Flag{
+	cli.Float64Flag{Name: "capacityStartRate", Value: 100, Usage: "offered rate of the first step in requests/s. The rate is doubled until a step violates the SLOs."},
+	cli.Float64Flag{Name: "capacityMaxRate", Value: 10000, Usage: "highest offered rate to try in requests/s"},
+	cli.Float64Flag{Name: "capacityResolution", Value: 0.05, Usage: "the binary search stops when the gap between the passing and the failing rate is within this ratio"},
+	cli.DurationFlag{Name: "capacityStepDuration", Value: 60 * time.Second, Usage: "duration of each step"},
+	cli.DurationFlag{Name: "capacityWarmUp", Value: 15 * time.Second, Usage: "beginning of each step which is not measured"},
+	cli.DurationFlag{Name: "capacityCooldown", Value: 30 * time.Second, Usage: "idle time after a failing step to drain the txpool"},
+	cli.IntFlag{Name: "capacityWorkers", Value: 500, Usage: "number of concurrent workers which run the TCs"},
+	cli.Float64Flag{Name: "sloFailureRate", Value: 0.01, Usage: "SLO: max ratio of failed requests"},
+	cli.DurationFlag{Name: "sloInclusionLatency", Value: 5 * time.Second, Usage: "SLO: max p95 time until a probe tx is mined"},
+	cli.Float64Flag{Name: "sloTxpoolRatio", Value: 0.8, Usage: "SLO: max average ratio of the txpool depth to txpoolCapacity. Ignored if txpoolMonitor is disabled."},
+	cli.Float64Flag{Name: "sloMinedRatio", Value: 0.9, Usage: "SLO: min ratio of the own mined TPS to the offered rate of the write TCs"},
+}
+
+// NewCapacityConfig reads the configuration of the capacity subcommand.
+func NewCapacityConfig(ctx *cli.Context) capacity.Config {
+	return capacity.Config{
+		StartRate:    ctx.Float64("capacityStartRate"),
+		MaxRate:      ctx.Float64("capacityMaxRate"),
+		Resolution:   ctx.Float64("capacityResolution"),
+		StepDuration: ctx.Duration("capacityStepDuration"),
+		WarmUp:       ctx.Duration("capacityWarmUp"),
+		Cooldown:     ctx.Duration("capacityCooldown"),
+		Workers:      ctx.Int("capacityWorkers"),
+		SLO: capacity.SLO{
+			MaxFailureRate:      ctx.Float64("sloFailureRate"),
+			MaxInclusionLatency: ctx.Duration("sloInclusionLatency"),
+			MaxTxPoolRatio:      ctx.Float64("sloTxpoolRatio"),
+			MinMinedRatio:       ctx.Float64("sloMinedRatio"),
+		},
+	}
+}
+
 var BoomerFlags = []cli.Flag{
 	cli.IntFlag{Name: "max-rps", Usage: "Maximum number of RPC calls"},
 	cli.StringFlag{Name: "master-host", Usage: "Url for the locust master"},
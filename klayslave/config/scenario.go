@@ -0,0 +1,102 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/kaiachain/kaia-load-tester/testcase"
+)
+
+// Scenario is the content of the scenario file, which lists the TCs to run with their weights and parameters.
+//
+//	{
+//	  "tcs": [
+//	    {"name": "transferSignedTx", "weight": 10},
+//	    {"name": "cpuHeavyTC", "weight": 5, "params": {"size": 500, "gasLimit": 3000000}},
+//	    {"name": "getLogsTC", "params": {"rangeWidths": [1, 10, 100], "namespaces": "eth"}}
+//	  ]
+//	}
+type Scenario struct {
+	TCs []ScenarioTC `json:"tcs"`
+}
+
+// ScenarioTC is a TC of the scenario file. The weight of the TC is used if Weight is omitted.
+// A parameter is a number, a string or a bool, and a list is an array or a string of the values separated by comma.
+type ScenarioTC struct {
+	Name   string                 `json:"name"`
+	Weight *int                   `json:"weight"`
+	Params map[string]interface{} `json:"params"`
+}
+
+// loadScenario sets the TCs, their weights and parameters from the scenario file.
+func (cfg *Config) loadScenario(file string) {
+	f, err := os.Open(file)
+	if err != nil {
+		log.Fatalf("Failed to open the scenario file: %v", err)
+	}
+	defer f.Close()
+
+	var scenario Scenario
+	decoder := json.NewDecoder(f)
+	decoder.UseNumber()
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&scenario); err != nil {
+		log.Fatalf("Failed to parse the scenario file %v: %v", file, err)
+	}
+	if len(scenario.TCs) == 0 {
+		log.Fatalf("No TC is set in the scenario file %v", file)
+	}
+
+	for _, tc := range scenario.TCs {
+		task, ok := testcase.TcList[tc.Name]
+		if !ok {
+			log.Fatalf("Unknown TC in the scenario file: %v", tc.Name)
+		}
+		if cfg.InTheTcList(tc.Name) {
+			log.Fatalf("Duplicated TC in the scenario file: %v", tc.Name)
+		}
+		weight := task.Weight
+		if tc.Weight != nil {
+			weight = *tc.Weight
+		}
+		cfg.tcNameList = append(cfg.tcNameList, tc.Name)
+		cfg.tcWeights = append(cfg.tcWeights, weight)
+
+		for name, v := range tc.Params {
+			value, err := scenarioParamValue(v)
+			if err == nil {
+				err = testcase.ValidateTCParam(tc.Name, name, value)
+			}
+			if err != nil {
+				log.Fatalf("Invalid parameter %v.%v in the scenario file: %v", tc.Name, name, err)
+			}
+			if cfg.tcParams[tc.Name] == nil {
+				cfg.tcParams[tc.Name] = make(map[string]string)
+			}
+			cfg.tcParams[tc.Name][name] = value
+		}
+	}
+}
+
+// scenarioParamValue returns the parameter value of the scenario file in the form of --tc-param.
+func scenarioParamValue(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case json.Number, string, bool:
+		return fmt.Sprint(v), nil
+	case []interface{}:
+		values := make([]string, len(v))
+		for i, elem := range v {
+			value, err := scenarioParamValue(elem)
+			if err != nil {
+				return "", err
+			}
+			values[i] = value
+		}
+		return strings.Join(values, ","), nil
+	default:
+		return "", fmt.Errorf("unsupported value %v, which should be a number, a string, a bool or an array of them", v)
+	}
+}
@@ -8,12 +8,19 @@ import (
 	"fmt"
 	"log"
 	"math/big"
+	"math/rand"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
+	"text/tabwriter"
+	"time"
 
 	"github.com/kaiachain/kaia-load-tester/klayslave/account"
+	"github.com/kaiachain/kaia-load-tester/klayslave/capacity"
 	"github.com/kaiachain/kaia-load-tester/klayslave/config"
+	"github.com/kaiachain/kaia-load-tester/klayslave/monitor"
+	"github.com/kaiachain/kaia-load-tester/klayslave/report"
 	"github.com/kaiachain/kaia-load-tester/testcase"
 	"github.com/kaiachain/kaia/accounts/abi/bind"
 	"github.com/kaiachain/kaia/api/debug"
@@ -25,6 +32,11 @@ import (
 
 var app = cli.NewApp()
 
+const (
+	probeTimeout      = 60 * time.Second
+	probePollInterval = 100 * time.Millisecond
+)
+
 func init() {
 	app.Name = filepath.Base(os.Args[0])
 	app.Usage = "This is for kaia load testing."
@@ -33,11 +45,19 @@ func init() {
 	app.Copyright = "Copyright 2024 Kaia-load-tester authors"
 	app.Flags = append(config.Flags, config.BoomerFlags...)
 
-	// This app doesn't provide any subcommand
-	//		app.Commands = []*cli.Command{}
-	//		sort.Sort(cli.CommandsByName(app.Commands))
-	//		app.CommandNotFound = nodecmd.CommandNotExist
-	// app.OnUsageError = nodecmd.OnUsageError
+	app.Commands = []cli.Command{
+		{
+			Name:   "capacity",
+			Usage:  "search the highest offered rate of the TC mix which meets the SLOs without the locust master",
+			Flags:  append(config.Flags, config.CapacityFlags...),
+			Action: RunCapacityAction,
+		},
+		{
+			Name:   "list-tcs",
+			Usage:  "list the TCs with their parameters, which can be set by --tc-param or the scenario file, and the defaults",
+			Action: RunListTCsAction,
+		},
+	}
 	app.Before = func(cli *cli.Context) error {
 		//runtime.GOMAXPROCS(runtime.NumCPU())
 		if runtime.GOOS == "darwin" {
@@ -62,7 +82,143 @@ func main() {
 
 func RunAction(ctx *cli.Context) {
 	cfg := config.NewConfig(ctx)
+	accGrp, txPoolMonitor := setUp(cfg, cfg.GetTxPoolBackpressure())
+
+	// Initialize refactored test cases (after contracts are deployed)
+	doneSetupStep := report.StartSetupStep("initialize tasks")
+	var throttle *monitor.TxPoolMonitor
+	if cfg.GetTxPoolBackpressure() {
+		throttle = txPoolMonitor
+	}
+	boomerTasks := initializeTasks(cfg, accGrp, cfg.GetExtendedTasks(), throttle)
+	doneSetupStep()
+	startReceiptVerifier(cfg)
+
+	if cfg.GetReportDir() != "" {
+		report.Start()
+	}
+	if cfg.GetBlockMonitor() {
+		startBlockMonitor(cfg, accGrp, cfg.GetBlockMonitorWindows())
+	}
+	boomer.Run(boomerTasks...)
+
+	// Write the end-of-run report once boomer is shut down
+	writeReport(cfg)
+}
+
+// RunCapacityAction runs the TC mix in closed loop, raising the offered rate step by step, and reports the knee point.
+// Boomer is not used, so the txpool backpressure is not applied either; it would hide the saturation.
+func RunCapacityAction(ctx *cli.Context) {
+	cfg := config.NewConfig(ctx)
+	capacityCfg := config.NewCapacityConfig(ctx)
+	accGrp, txPoolMonitor := setUp(cfg, false)
+
+	doneSetupStep := report.StartSetupStep("initialize tasks")
+	extendedTasks := cfg.GetExtendedTasks()
+	boomerTasks := initializeTasks(cfg, accGrp, extendedTasks, nil)
+	doneSetupStep()
+	startReceiptVerifier(cfg)
+
+	var tasks []capacity.Task
+	for i, task := range boomerTasks {
+		// The txs of the NoBackpressure TCs are rejected, so they are not expected to be mined either
+		readOnly := extendedTasks[i].ReadOnly || extendedTasks[i].NoBackpressure
+		tasks = append(tasks, capacity.Task{Name: task.Name, Weight: task.Weight, Fn: task.Fn, ReadOnly: readOnly})
+	}
+
+	if cfg.GetReportDir() != "" {
+		report.Start()
+	}
+	// The longest window of the block monitor covers the measured part of a step
+	windows := append([]time.Duration{capacityCfg.StepDuration - capacityCfg.WarmUp}, cfg.GetBlockMonitorWindows()...)
+	sort.Slice(windows, func(i, j int) bool { return windows[i] < windows[j] })
+	blockMonitor := startBlockMonitor(cfg, accGrp, windows)
+	if blockMonitor == nil {
+		log.Fatal("The capacity search requires the block monitor")
+	}
+
+	searcher, err := capacity.NewSearcher(capacityCfg, tasks, blockMonitor, txPoolMonitor, newInclusionProbe(cfg, accGrp))
+	if err != nil {
+		log.Fatalf("Failed to create the capacity searcher: %v", err)
+	}
+	report.SetCapacity(searcher.Run())
+	writeReport(cfg)
+}
+
+// RunListTCsAction prints the TCs in the name order with their parameters and the defaults.
+func RunListTCsAction(ctx *cli.Context) {
+	names := make([]string, 0, len(testcase.TcList))
+	for name := range testcase.TcList {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, name := range names {
+		fmt.Fprintf(w, "%s\n", name)
+		for _, p := range testcase.TcList[name].Params {
+			fmt.Fprintf(w, "  %s.%s=%v\t%s\n", name, p.Name, p.Default, p.Usage)
+		}
+	}
+	w.Flush()
+}
+
+// newInclusionProbe returns a probe which sends a value transfer from a signed tx account to itself and measures
+// the time until its receipt is available.
+func newInclusionProbe(cfg *config.Config, accGrp *account.AccGroup) capacity.Probe {
+	accs := accGrp.GetAccListByName(account.AccListForSignedTx)
+	return func() (time.Duration, error) {
+		if len(accs) == 0 {
+			return 0, fmt.Errorf("no signed tx account")
+		}
+		acc := accs[rand.Intn(len(accs))]
+		hash, _, err := acc.TransferNewValueTransferTx(cfg.GetGCli(), acc, big.NewInt(0))
+		if err != nil {
+			return 0, err
+		}
+		start := time.Now()
+		for time.Since(start) < probeTimeout {
+			if receipt, _ := cfg.GetGCli().TransactionReceipt(context.Background(), hash); receipt != nil {
+				return time.Since(start), nil
+			}
+			time.Sleep(probePollInterval)
+		}
+		return 0, fmt.Errorf("probe tx %v is not mined in %v", hash.String(), probeTimeout)
+	}
+}
+
+// startReceiptVerifier starts verifying the receipts of the sampled txs if verify-ratio is set.
+func startReceiptVerifier(cfg *config.Config) {
+	if err := testcase.StartReceiptVerifier(cfg.GetGEndpoint(), cfg.GetVerifyRatio()); err != nil {
+		log.Fatalf("Failed to start the receipt verifier: %v", err)
+	}
+}
+
+// writeReport writes the end-of-run report if the report is enabled.
+func writeReport(cfg *config.Config) {
+	if cfg.GetReportDir() == "" {
+		return
+	}
+	jsonPath, htmlPath, err := report.Write(cfg.GetReportDir())
+	if err != nil {
+		log.Printf("Failed to write the report: %v", err)
+	} else {
+		log.Printf("Report is written to %v and %v", jsonPath, htmlPath)
+	}
+}
+
+// setUp creates and charges the test accounts and deploys the test contracts. It returns the txpool monitor if enabled.
+// If backpressure is true, the setup retries also wait until the txpool has room.
+func setUp(cfg *config.Config, backpressure bool) (*account.AccGroup, *monitor.TxPoolMonitor) {
+	setReportMetadata(cfg)
 	accGrp := account.NewAccGroup(cfg.GetChainID(), cfg.GetGasPrice(), cfg.GetBaseFee(), cfg.InTheTcList("transferUnsignedTx"))
+	if cfg.UseHDAccounts() {
+		hdWallet, err := account.NewHDWallet(cfg.GetMnemonic(), cfg.GetSeed())
+		if err != nil {
+			log.Fatalf("Failed to create HD wallet: %v", err)
+		}
+		accGrp.SetHDWallet(hdWallet, cfg.GetSlaveIndex())
+	}
 	var nUserForGaslessRevertTx, nUserForGaslessApproveTx int = 0, 0
 	if cfg.InTheTcList("gaslessRevertTransactionTC") {
 		nUserForGaslessRevertTx = cfg.GetNUserForSigned() // same as nUserForSignedTx
@@ -70,13 +226,95 @@ func RunAction(ctx *cli.Context) {
 	if cfg.InTheTcList("gaslessOnlyApproveTC") {
 		nUserForGaslessApproveTx = cfg.GetNUserForSigned() // same as nUserForSignedTx
 	}
-	accGrp.CreateAccountsPerAccGrp(cfg.GetNUserForSigned(), cfg.GetNUserForUnsigned(), cfg.GetNUserForNewAccounts(), nUserForGaslessRevertTx, nUserForGaslessApproveTx, cfg.GetTcStrList(), cfg.GetGEndpoint())
+	var nUserForPublicKeyTx, nUserForMultiSigTx, nUserForRoleBasedTx int = 0, 0, 0
+	if cfg.InTheTcList("publicKeyValueTransferTC") || cfg.InTheTcList("publicKeySmartContractExecutionTC") {
+		nUserForPublicKeyTx = cfg.GetNUserForSigned() // same as nUserForSignedTx
+	}
+	if cfg.InTheTcList("multisigValueTransferTC") || cfg.InTheTcList("multisigSmartContractExecutionTC") {
+		nUserForMultiSigTx = cfg.GetNUserForSigned() // same as nUserForSignedTx
+	}
+	if cfg.InTheTcList("roleBasedValueTransferTC") || cfg.InTheTcList("roleBasedSmartContractExecutionTC") {
+		nUserForRoleBasedTx = cfg.GetNUserForSigned() // same as nUserForSignedTx
+	}
+	account.SetMultiSigConfig(cfg.GetMultiSigThreshold(), cfg.GetMultiSigNumKeys())
+	account.SetRoleKeyType(cfg.GetRoleKeyType())
+	var nUserForNewAccounts int = 0
+	if cfg.InTheTcList("newAccountCreationTC") {
+		nUserForNewAccounts = cfg.GetNUserForNewAccounts()
+	}
+	testcase.SetAccountCreationConfig(cfg.GetNewAccountKeyType(), cfg.GetNewAccountValue(), cfg.GetReuseNewAccounts())
+	testcase.SetTCParams(cfg.GetTCParams())
+	account.SetTestTokenConfig(big.NewInt(int64(testcase.IntParam(testcase.Erc20TransferTCName, "charge"))), testcase.IntParam(testcase.Erc721TransferTCName, "mintPerAccount"))
+	doneSetupStep := report.StartSetupStep("create test accounts")
+	accGrp.CreateAccountsPerAccGrp(cfg.GetNUserForSigned(), cfg.GetNUserForUnsigned(), nUserForNewAccounts, nUserForGaslessRevertTx, nUserForGaslessApproveTx, nUserForPublicKeyTx, nUserForMultiSigTx, nUserForRoleBasedTx, cfg.GetTcStrList(), cfg.GetGEndpoint())
+	doneSetupStep()
+
+	// The txpool monitor starts before the setup, so that the setup also waits until the txpool has room
+	txPoolMonitor := startTxPoolMonitor(cfg, accGrp)
+	if txPoolMonitor != nil && backpressure {
+		account.SetRetryBackoff(func() {
+			time.Sleep(1 * time.Second)
+			txPoolMonitor.Wait()
+		})
+	}
 
 	createTestAccGroupsAndPrepareContracts(cfg, accGrp)
+	return accGrp, txPoolMonitor
+}
 
-	// Initialize refactored test cases (after contracts are deployed)
-	boomerTasks := initializeTasks(cfg, accGrp, cfg.GetExtendedTasks())
-	boomer.Run(boomerTasks...)
+// startBlockMonitor follows the blocks of the target endpoint and counts the txs sent from the test accounts as own txs.
+func startBlockMonitor(cfg *config.Config, accGrp *account.AccGroup, windows []time.Duration) *monitor.BlockMonitor {
+	m, err := monitor.NewBlockMonitor(cfg.GetGEndpoint(), windows)
+	if err != nil {
+		log.Printf("Failed to start the block monitor: %v", err)
+		return nil
+	}
+	for accList := account.AccList(0); accList < account.AccListEnd; accList++ {
+		for _, acc := range accGrp.GetAccListByName(accList) {
+			m.AddOwnAddresses(acc.GetAddress())
+		}
+	}
+	m.Start()
+	return m
+}
+
+// startTxPoolMonitor polls the txpool of the target endpoint. It returns nil if the txpool monitor is disabled.
+func startTxPoolMonitor(cfg *config.Config, accGrp *account.AccGroup) *monitor.TxPoolMonitor {
+	if !cfg.GetTxPoolMonitor() {
+		return nil
+	}
+	low, high := cfg.GetTxPoolWatermarks()
+	m, err := monitor.NewTxPoolMonitor(cfg.GetGEndpoint(), cfg.GetTxPoolCapacity(), low, high, cfg.GetTxPoolInspect())
+	if err != nil {
+		log.Printf("Failed to start the txpool monitor: %v", err)
+		return nil
+	}
+	for accList := account.AccList(0); accList < account.AccListEnd; accList++ {
+		for _, acc := range accGrp.GetAccListByName(accList) {
+			m.AddOwnAddresses(acc.GetAddress())
+		}
+	}
+	m.Start()
+	return m
+}
+
+// setReportMetadata records the run metadata for the end-of-run report.
+func setReportMetadata(cfg *config.Config) {
+	hostname, _ := os.Hostname()
+	var scenario []report.ScenarioTC
+	for _, task := range cfg.GetExtendedTasks() {
+		scenario = append(scenario, report.ScenarioTC{Name: task.Name, Weight: task.Weight})
+	}
+	report.SetMetadata(report.Metadata{
+		ToolVersion: config.GetVersionWithCommit(),
+		NodeVersion: cfg.GetNodeVersion(),
+		ChainID:     cfg.GetChainID().String(),
+		Endpoint:    cfg.GetGEndpoint(),
+		SlaveIndex:  cfg.GetSlaveIndex(),
+		Hostname:    hostname,
+		Scenario:    scenario,
+		Flags:       cfg.GetFlagValues(),
+	})
 }
 
 // createTestAccGroupsAndPrepareContracts do every init steps before task.Init
@@ -91,16 +329,19 @@ func createTestAccGroupsAndPrepareContracts(cfg *config.Config, accGrp *account.
 	localReservoirAccount := account.NewAccount(0)
 
 	// 2. charge local reservoir
+	doneSetupStep := report.StartSetupStep("charge local reservoir")
 	_ = globalReservoirAccount.GetNonce(cfg.GetGCli())
 	revertGroupChargeValue := new(big.Int).Mul(cfg.GetChargeValue(), big.NewInt(int64(len(accGrp.GetAccListByName(account.AccListForGaslessRevertTx)))))
 	approveGroupChargeValue := new(big.Int).Mul(cfg.GetChargeValue(), big.NewInt(int64(len(accGrp.GetAccListByName(account.AccListForGaslessApproveTx)))))
 	forAuctionDepositChargeValue := new(big.Int).Mul(cfg.GetChargeValue(), big.NewInt(int64(len(accGrp.GetAccListByName(account.AccListForSignedTx)))))
+	dedicatedGroupChargeValue := new(big.Int).Mul(cfg.GetChargeValue(), big.NewInt(int64(len(accGrp.GetAccListByName(account.AccListForNewAccounts))+len(accGrp.GetAccListByName(account.AccListForPublicKeyTx))+len(accGrp.GetAccListByName(account.AccListForMultiSigTx))+len(accGrp.GetAccListByName(account.AccListForRoleBasedTx)))))
 	initialLiquidity := common.Big0
 	if !account.IsGSRExistInRegistry(cfg.GetGCli()) {
 		// If GSR does not exist, charge initial liquidity to the local reservoir
 		initialLiquidity = account.GetInitialLiquidity()
 	}
 	totalChargeValue := new(big.Int).Add(cfg.GetTotalChargeValue(), new(big.Int).Add(initialLiquidity, new(big.Int).Add(forAuctionDepositChargeValue, new(big.Int).Add(revertGroupChargeValue, approveGroupChargeValue))))
+	totalChargeValue.Add(totalChargeValue, dedicatedGroupChargeValue)
 	tx := globalReservoirAccount.TransferSignedTxWithGuaranteeRetry(cfg.GetGCli(), localReservoirAccount, totalChargeValue)
 	receipt, err := bind.WaitMined(context.Background(), cfg.GetGCli(), tx)
 	if err != nil {
@@ -109,26 +350,39 @@ func createTestAccGroupsAndPrepareContracts(cfg *config.Config, accGrp *account.
 	if receipt.Status != 1 {
 		log.Fatalf("transfer for reservoir failed, localReservoir")
 	}
+	doneSetupStep()
 
 	// 3. charge KAIA
+	doneSetupStep = report.StartSetupStep("charge test accounts")
 	log.Printf("Start charging KLAY to test accounts")
 	accs := accGrp.GetValidAccGrp()
 	accs = append(accs, accGrp.GetAccListByName(account.AccListForGaslessRevertTx)...)  // for avoid validation
 	accs = append(accs, accGrp.GetAccListByName(account.AccListForGaslessApproveTx)...) // for avoid validation
+	accs = append(accs, accGrp.GetAccListByName(account.AccListForNewAccounts)...)
+	accs = append(accs, accGrp.GetAccListByName(account.AccListForPublicKeyTx)...)
+	accs = append(accs, accGrp.GetAccListByName(account.AccListForMultiSigTx)...)
+	accs = append(accs, accGrp.GetAccListByName(account.AccListForRoleBasedTx)...)
+	if cfg.InTheTargetTxTypeList("FD") {
+		accs = append(accs, account.AuctionFeePayer) // pays the fee of the FD auction target txs
+	}
 	account.ConcurrentTransactionSend(accs, cfg.GetChargeParallelNum(), func(acc *account.Account) {
 		localReservoirAccount.TransferSignedTxWithGuaranteeRetry(cfg.GetGCli(), acc, cfg.GetChargeValue())
 	})
 	log.Printf("Finished charging KLAY to %d test account(s)\n", len(accs))
+	doneSetupStep()
 
 	// Wait, charge KAIA happen in 100% of all created test accounts
 	// But, from here including prepareTestContracts like MintERC721, only 20% of account happens
 	accGrp.SetAccGrpByActivePercent(cfg.GetActiveUserPercent())
 
 	// 4. Deploy the test contracts which will be used in various TCs. If needed, charge tokens to test accounts.
+	doneSetupStep = report.StartSetupStep("deploy test contracts")
 	accGrp.DeployTestContracts(cfg.GetTcStrList(), cfg.GetAuctionTargetTxTypeList(), localReservoirAccount, cfg.GetGCli(), cfg.GetChargeValue(), cfg.GetChargeParallelNum())
+	doneSetupStep()
 
 	// 5. Setup liquidity and register GSR if tc is gaslessTransactionTC, gaslessRevertTransactionTC, or gaslessOnlyApproveTC
-	needGaslessSetup := cfg.InTheTcList("gaslessTransactionTC") || cfg.InTheTcList("gaslessRevertTransactionTC") || cfg.InTheTcList("gaslessOnlyApproveTC") || cfg.InTheTargetTxTypeList("GAA", "GAS", "rGAA", "rGAS")
+	needGaslessSetup := cfg.InTheTcList("gaslessTransactionTC") || cfg.InTheTcList("gaslessRevertTransactionTC") || cfg.InTheTcList("gaslessOnlyApproveTC") || cfg.InTheTargetTxTypeList("GAA", "GAS", "rGAA", "rGAS", "DEX", "rDEX")
+	doneSetupStep = report.StartSetupStep("setup gasless")
 	if !account.IsGSRExistInRegistry(cfg.GetGCli()) && needGaslessSetup {
 		log.Printf("GSR does not exist in registry, setting up liquidity and registering GSR...")
 
@@ -147,9 +401,11 @@ func createTestAccGroupsAndPrepareContracts(cfg *config.Config, accGrp *account.
 		// Register GSR
 		account.RegisterGSR(cfg.GetGCli(), accGrp, globalReservoirAccount)
 	}
+	doneSetupStep()
 
-	// 6. Register Auction Entry Point if tc is auctionBidTC or auctionRevertedBidTC
-	auctionInTc := cfg.InTheTcList("auctionBidTC") || cfg.InTheTcList("auctionRevertedBidTC")
+	// 6. Register Auction Entry Point if an auction tc is set
+	auctionInTc := cfg.InTheTcList("auctionBidTC") || cfg.InTheTcList("auctionRevertedBidTC") || cfg.InTheTcList("auctionCompetitiveBidTC")
+	doneSetupStep = report.StartSetupStep("setup auction")
 	if !account.IsAuctionEntryPointExistInRegistry(cfg.GetGCli()) && auctionInTc {
 		log.Printf("Auction Entry Point does not exist in registry, registering Auction Entry Point...")
 
@@ -157,7 +413,7 @@ func createTestAccGroupsAndPrepareContracts(cfg *config.Config, accGrp *account.
 		account.RegisterAuctionEntryPoint(cfg.GetGCli(), accGrp, globalReservoirAccount)
 	}
 
-	// 7. Deposit to the Auction Contract for each account if tc is auctionBidTC or auctionRevertedBidTC
+	// 7. Deposit to the Auction Contract for each account if an auction tc is set
 	if auctionInTc {
 		log.Printf("Start depositing to the Auction Contract for each account")
 		account.ConcurrentTransactionSend(accGrp.GetValidAccGrp(), cfg.GetChargeParallelNum(), func(acc *account.Account) {
@@ -169,21 +425,39 @@ func createTestAccGroupsAndPrepareContracts(cfg *config.Config, accGrp *account.
 			)
 		})
 	}
+	doneSetupStep()
+
+	// 8. Migrate the account keys of the accounts dedicated to the key type TCs
+	doneSetupStep = report.StartSetupStep("migrate account keys")
+	for accList, keyType := range map[account.AccList]account.AccountKeyType{
+		account.AccListForPublicKeyTx: account.AccountKeyTypePublic,
+		account.AccListForMultiSigTx:  account.AccountKeyTypeMultiSig,
+		account.AccListForRoleBasedTx: account.AccountKeyTypeRoleBased,
+	} {
+		if len(accGrp.GetAccListByName(accList)) > 0 {
+			accGrp.MigrateAccountKeys(cfg.GetGCli(), cfg.GetGEndpoint(), accList, keyType, cfg.GetChargeParallelNum())
+		}
+	}
+	doneSetupStep()
 
 	return localReservoirAccount
 }
 
-func initializeTasks(cfg *config.Config, accGrp *account.AccGroup, tasks []*testcase.ExtendedTask) []*boomer.Task {
+func initializeTasks(cfg *config.Config, accGrp *account.AccGroup, tasks []*testcase.ExtendedTask, txPoolMonitor *monitor.TxPoolMonitor) []*boomer.Task {
 	println("Initializing tasks")
 	var boomerTasks []*boomer.Task
 
 	// Tc package initializes the task
 	for _, extendedTask := range tasks {
 		config := extendedTask.Init(accGrp, cfg.GetGEndpoint(), extendedTask.TestContracts, extendedTask.Name, cfg.GetAuctionTargetTxTypeList())
+		fn := extendedTask.Run(config)
+		if txPoolMonitor != nil && !extendedTask.ReadOnly && !extendedTask.NoBackpressure {
+			fn = txPoolMonitor.Wrap(fn)
+		}
 		boomerTask := &boomer.Task{
 			Name:   extendedTask.Name,
 			Weight: extendedTask.Weight,
-			Fn:     extendedTask.Run(config),
+			Fn:     fn,
 		}
 		boomerTasks = append(boomerTasks, boomerTask)
 		println("=> " + extendedTask.Name + " extendedTask is initialized.")
@@ -0,0 +1,261 @@
+// Package monitor follows the blocks of the target endpoint and measures what the chain actually processed.
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/kaiachain/kaia-load-tester/klayslave/report"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/networks/rpc"
+	"github.com/myzhan/boomer"
+)
+
+const (
+	pollInterval   = 200 * time.Millisecond
+	reportInterval = 10 * time.Second
+)
+
+// rpcBlock is the subset of the block fields returned by kaia_getBlockByNumber which the monitor uses.
+type rpcBlock struct {
+	Number       hexutil.Uint64 `json:"number"`
+	Timestamp    hexutil.Uint64 `json:"timestamp"`
+	TimestampFoS hexutil.Uint64 `json:"timestampFoS"`
+	GasUsed      hexutil.Uint64 `json:"gasUsed"`
+	BaseFee      *hexutil.Big   `json:"baseFeePerGas"`
+	Transactions []struct {
+		From common.Address `json:"from"`
+	} `json:"transactions"`
+}
+
+// BlockStat holds the statistics of a block.
+type BlockStat struct {
+	Number     uint64
+	Time       float64 // unix time in seconds including the fraction of second
+	TxCount    int
+	OwnTxCount int
+	GasUsed    uint64
+	BaseFee    *big.Int
+	Interval   float64 // seconds since the parent block
+}
+
+// WindowStat holds the statistics of the blocks in a sliding window.
+type WindowStat struct {
+	Window       time.Duration
+	TPS          float64
+	GasPerSec    float64
+	AvgBlockTime float64
+	OwnTxShare   float64
+}
+
+// BlockMonitor follows new blocks and computes on-chain TPS and gas/sec over sliding windows.
+type BlockMonitor struct {
+	cli     *rpc.Client
+	windows []time.Duration
+
+	mu      sync.Mutex
+	own     map[common.Address]struct{}
+	history []BlockStat // blocks within the longest window and one more as the base of the window
+}
+
+// NewBlockMonitor creates a block monitor for the endpoint. The windows are sorted by the caller, the shortest first.
+func NewBlockMonitor(endpoint string, windows []time.Duration) (*BlockMonitor, error) {
+	if len(windows) == 0 {
+		return nil, fmt.Errorf("at least one window is required")
+	}
+	cli, err := rpc.Dial(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return &BlockMonitor{cli: cli, windows: windows, own: make(map[common.Address]struct{})}, nil
+}
+
+// AddOwnAddresses registers the senders whose txs are counted as the txs of this load tester.
+func (m *BlockMonitor) AddOwnAddresses(addrs ...common.Address) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, addr := range addrs {
+		m.own[addr] = struct{}{}
+	}
+}
+
+// Start follows the blocks from the latest one in background.
+func (m *BlockMonitor) Start() {
+	go m.loop()
+	go m.logLoop()
+}
+
+func (m *BlockMonitor) loop() {
+	var next uint64
+	for {
+		var head hexutil.Uint64
+		if err := m.cli.CallContext(context.Background(), &head, "kaia_blockNumber"); err != nil {
+			log.Printf("Block monitor: failed to get block number: %v", err)
+			time.Sleep(time.Second)
+			continue
+		}
+		if next == 0 {
+			next = uint64(head)
+		}
+		for ; next <= uint64(head); next++ {
+			stat, err := m.fetchBlock(next)
+			if err != nil {
+				log.Printf("Block monitor: failed to get block %d: %v", next, err)
+				break
+			}
+			m.onBlock(stat)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+func (m *BlockMonitor) fetchBlock(number uint64) (BlockStat, error) {
+	var b *rpcBlock
+	if err := m.cli.CallContext(context.Background(), &b, "kaia_getBlockByNumber", hexutil.Uint64(number), true); err != nil {
+		return BlockStat{}, err
+	}
+	if b == nil {
+		return BlockStat{}, fmt.Errorf("block not found")
+	}
+
+	stat := BlockStat{
+		Number:  uint64(b.Number),
+		Time:    float64(b.Timestamp) + float64(b.TimestampFoS)/100,
+		TxCount: len(b.Transactions),
+		GasUsed: uint64(b.GasUsed),
+		BaseFee: new(big.Int),
+	}
+	if b.BaseFee != nil {
+		stat.BaseFee = b.BaseFee.ToInt()
+	}
+
+	m.mu.Lock()
+	for _, tx := range b.Transactions {
+		if _, ok := m.own[tx.From]; ok {
+			stat.OwnTxCount++
+		}
+	}
+	m.mu.Unlock()
+
+	return stat, nil
+}
+
+func (m *BlockMonitor) onBlock(stat BlockStat) {
+	m.mu.Lock()
+	if n := len(m.history); n > 0 && m.history[n-1].Number+1 == stat.Number {
+		stat.Interval = stat.Time - m.history[n-1].Time
+	}
+	m.history = append(m.history, stat)
+
+	// Keep one block older than the longest window as the base of it
+	longest := m.windows[len(m.windows)-1].Seconds()
+	for len(m.history) > 2 && m.history[1].Time <= stat.Time-longest {
+		m.history = m.history[1:]
+	}
+	windowStats := m.windowStatsLocked()
+	m.mu.Unlock()
+
+	report.RecordBlock(report.BlockPoint{
+		Number:       stat.Number,
+		TxCount:      stat.TxCount,
+		OwnTxCount:   stat.OwnTxCount,
+		GasUsed:      stat.GasUsed,
+		BaseFee:      stat.BaseFee.String(),
+		BlockTimeSec: stat.Interval,
+		TPS:          windowStats[0].TPS,
+		GasPerSec:    windowStats[0].GasPerSec,
+	})
+
+	// Locust has no custom metric, so the on-chain statistics are reported as the response time of pseudo requests.
+	for _, w := range windowStats {
+		boomer.Events.Publish("request_success", report.OnChainRequestType, fmt.Sprintf("mined TPS (%v)", w.Window), int64(w.TPS), int64(0))
+		boomer.Events.Publish("request_success", report.OnChainRequestType, fmt.Sprintf("mined Mgas/s (%v)", w.Window), int64(w.GasPerSec/1e6), int64(0))
+	}
+	boomer.Events.Publish("request_success", report.OnChainRequestType, "block time (ms)", int64(stat.Interval*1000), int64(stat.TxCount))
+}
+
+// windowStatsLocked computes the statistics of every window from the history. m.mu must be held.
+func (m *BlockMonitor) windowStatsLocked() []WindowStat {
+	stats := make([]WindowStat, len(m.windows))
+	for i, window := range m.windows {
+		stats[i] = m.windowStatLocked(window)
+	}
+	return stats
+}
+
+// windowStatLocked computes the statistics of the blocks in the window ending at the latest block. m.mu must be held.
+func (m *BlockMonitor) windowStatLocked(window time.Duration) WindowStat {
+	stat := WindowStat{Window: window}
+	if len(m.history) == 0 {
+		return stat
+	}
+	last := m.history[len(m.history)-1]
+
+	var txs, ownTxs, blocks int
+	var gasUsed uint64
+	base := m.history[0]
+	for j := len(m.history) - 1; j > 0; j-- {
+		b := m.history[j]
+		if b.Time <= last.Time-window.Seconds() {
+			base = b
+			break
+		}
+		txs += b.TxCount
+		ownTxs += b.OwnTxCount
+		gasUsed += b.GasUsed
+		blocks++
+	}
+
+	span := last.Time - base.Time
+	if span <= 0 || blocks == 0 {
+		return stat
+	}
+	stat.TPS = float64(txs) / span
+	stat.GasPerSec = float64(gasUsed) / span
+	stat.AvgBlockTime = span / float64(blocks)
+	if txs > 0 {
+		stat.OwnTxShare = float64(ownTxs) / float64(txs)
+	}
+	return stat
+}
+
+// Stats returns the latest statistics of the given window. The window should not exceed the longest window of the monitor.
+func (m *BlockMonitor) Stats(window time.Duration) WindowStat {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.windowStatLocked(window)
+}
+
+// WindowStats returns the latest statistics of every window.
+func (m *BlockMonitor) WindowStats() []WindowStat {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.history) == 0 {
+		return nil
+	}
+	return m.windowStatsLocked()
+}
+
+// logLoop periodically prints the on-chain statistics to the console.
+func (m *BlockMonitor) logLoop() {
+	for range time.Tick(reportInterval) {
+		m.mu.Lock()
+		if len(m.history) == 0 {
+			m.mu.Unlock()
+			continue
+		}
+		last := m.history[len(m.history)-1]
+		stats := m.windowStatsLocked()
+		m.mu.Unlock()
+
+		for _, w := range stats {
+			log.Printf("Block monitor: block #%d, window %v: mined TPS %.1f, gas/s %.0f, avg block time %.3fs, own tx share %.1f%%, base fee %v",
+				last.Number, w.Window, w.TPS, w.GasPerSec, w.AvgBlockTime, w.OwnTxShare*100, last.BaseFee)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package monitor
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/kaiachain/kaia-load-tester/klayslave/report"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/networks/rpc"
+	"github.com/myzhan/boomer"
+)
+
+const (
+	txPoolPollInterval = 500 * time.Millisecond
+
+	// maxThrottleDelay is the delay before a write request when the pool depth is right below the high watermark.
+	maxThrottleDelay = 500 * time.Millisecond
+
+	// maxTxPoolFailures is the number of consecutive failed polls after which the observed depth is regarded as stale
+	// and the write requests are not throttled any more, so that they are not paused forever by a node which stopped answering.
+	maxTxPoolFailures = 10
+)
+
+// TxPoolMonitor polls the txpool of the target endpoint and throttles the write requests when the pool nears capacity.
+// Below the low watermark, requests are not delayed. Between the watermarks, they are delayed in proportion to the depth.
+// At or above the high watermark, they are paused until the depth drops below it.
+// If the txpool cannot be polled for maxTxPoolFailures times in a row, the requests are let through until a poll succeeds.
+type TxPoolMonitor struct {
+	cli      *rpc.Client
+	capacity int
+	low      float64
+	high     float64
+	inspect  bool
+
+	mu         sync.RWMutex
+	own        map[common.Address]struct{}
+	pending    int
+	queued     int
+	ownPending int
+	ownQueued  int
+	paused     bool
+	failures   int // consecutive failed polls
+}
+
+// NewTxPoolMonitor creates a txpool monitor. The watermarks are the ratio of pending and queued txs to the capacity.
+// If inspect is true, the txs of the own addresses are also counted with txpool_inspect.
+func NewTxPoolMonitor(endpoint string, capacity int, low, high float64, inspect bool) (*TxPoolMonitor, error) {
+	cli, err := rpc.Dial(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return &TxPoolMonitor{
+		cli:      cli,
+		capacity: capacity,
+		low:      low,
+		high:     high,
+		inspect:  inspect,
+		own:      make(map[common.Address]struct{}),
+	}, nil
+}
+
+// AddOwnAddresses registers the senders whose txs are counted by txpool_inspect.
+func (m *TxPoolMonitor) AddOwnAddresses(addrs ...common.Address) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, addr := range addrs {
+		m.own[addr] = struct{}{}
+	}
+}
+
+// Start polls the txpool in background.
+func (m *TxPoolMonitor) Start() {
+	go m.loop()
+}
+
+func (m *TxPoolMonitor) loop() {
+	for range time.Tick(txPoolPollInterval) {
+		var status map[string]hexutil.Uint
+		if err := m.cli.CallContext(context.Background(), &status, "txpool_status"); err != nil {
+			m.onPollFailure(err)
+			continue
+		}
+
+		ownPending, ownQueued := 0, 0
+		if m.inspect {
+			ownPending, ownQueued = m.countOwnTxs()
+		}
+
+		m.mu.Lock()
+		if m.failures >= maxTxPoolFailures {
+			log.Printf("Txpool monitor: txpool_status is available again after %d failure(s)", m.failures)
+		}
+		m.failures = 0
+		m.pending, m.queued = int(status["pending"]), int(status["queued"])
+		m.ownPending, m.ownQueued = ownPending, ownQueued
+		ratio := m.ratioLocked()
+		if !m.paused && ratio >= m.high {
+			m.paused = true
+			log.Printf("Txpool monitor: pausing write TCs, pending=%d queued=%d capacity=%d", m.pending, m.queued, m.capacity)
+		} else if m.paused && ratio < m.high {
+			m.paused = false
+			log.Printf("Txpool monitor: resuming write TCs, pending=%d queued=%d capacity=%d", m.pending, m.queued, m.capacity)
+		}
+		point := report.TxPoolPoint{Pending: m.pending, Queued: m.queued, OwnPending: m.ownPending, OwnQueued: m.ownQueued}
+		m.mu.Unlock()
+
+		report.RecordTxPool(point)
+		boomer.Events.Publish("request_success", report.OnChainRequestType, "txpool pending", int64(point.Pending), int64(0))
+		boomer.Events.Publish("request_success", report.OnChainRequestType, "txpool queued", int64(point.Queued), int64(0))
+	}
+}
+
+// onPollFailure logs the failed poll. Once the polls failed maxTxPoolFailures times in a row, the observed depth is stale,
+// so the write TCs are resumed.
+func (m *TxPoolMonitor) onPollFailure(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failures++
+	switch {
+	case m.failures < maxTxPoolFailures:
+		log.Printf("Txpool monitor: failed to get txpool_status, check that the txpool API is enabled: %v", err)
+	case m.failures == maxTxPoolFailures:
+		log.Printf("Txpool monitor: failed to get txpool_status %d times in a row, not throttling write TCs until it succeeds: %v", m.failures, err)
+		m.paused = false
+	}
+}
+
+// countOwnTxs counts the pending and queued txs sent from the own addresses.
+func (m *TxPoolMonitor) countOwnTxs() (int, int) {
+	var content map[string]map[string]map[string]string
+	if err := m.cli.CallContext(context.Background(), &content, "txpool_inspect"); err != nil {
+		log.Printf("Txpool monitor: failed to get txpool_inspect: %v", err)
+		return 0, 0
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	count := func(txs map[string]map[string]string) int {
+		n := 0
+		for addr, nonces := range txs {
+			if _, ok := m.own[common.HexToAddress(addr)]; ok {
+				n += len(nonces)
+			}
+		}
+		return n
+	}
+	return count(content["pending"]), count(content["queued"])
+}
+
+func (m *TxPoolMonitor) ratioLocked() float64 {
+	if m.capacity <= 0 {
+		return 0
+	}
+	return float64(m.pending+m.queued) / float64(m.capacity)
+}
+
+// Ratio returns the ratio of pending and queued txs to the capacity of the txpool.
+func (m *TxPoolMonitor) Ratio() float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.ratioLocked()
+}
+
+// Wait blocks while the txpool is at or above the high watermark, and delays between the watermarks.
+// It does not wait if the observed depth is stale.
+func (m *TxPoolMonitor) Wait() {
+	for {
+		m.mu.RLock()
+		ratio, stale := m.ratioLocked(), m.failures >= maxTxPoolFailures
+		m.mu.RUnlock()
+
+		if stale {
+			return
+		}
+		if ratio >= m.high {
+			time.Sleep(txPoolPollInterval)
+			continue
+		}
+		if ratio > m.low {
+			time.Sleep(time.Duration(float64(maxThrottleDelay) * (ratio - m.low) / (m.high - m.low)))
+		}
+		return
+	}
+}
+
+// Wrap returns the task function which waits for the txpool before every request.
+func (m *TxPoolMonitor) Wrap(fn func()) func() {
+	return func() {
+		m.Wait()
+		fn()
+	}
+}
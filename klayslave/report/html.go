@@ -0,0 +1,330 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+)
+
+const (
+	chartWidth  = 900
+	chartHeight = 240
+	chartMargin = 40
+)
+
+// chartColors is used in turn for the series of a chart.
+var chartColors = []string{"#1f77b4", "#ff7f0e", "#2ca02c", "#d62728", "#9467bd", "#8c564b", "#e377c2", "#7f7f7f", "#bcbd22", "#17becf"}
+
+type series struct {
+	Name   string
+	Color  string
+	Points string // SVG polyline points
+}
+
+type lineChart struct {
+	Width, Height int
+	MaxY          uint64
+	MaxX          int64
+	Series        []series
+}
+
+type bar struct {
+	Label     string
+	Color     string
+	X, Y      int
+	W, H      int
+	ValueText string
+}
+
+type barChart struct {
+	Width, Height int
+	Bars          []bar
+	Labels        []bar // x-axis labels, one per test case
+}
+
+// newLineChart draws the values of every series on the same axes. The x-axis is the second since the load started.
+func newLineChart(timeline []TimelinePoint, names []string, valueOf func(p TimelinePoint, name string) uint64) lineChart {
+	chart := lineChart{Width: chartWidth, Height: chartHeight}
+	if len(timeline) == 0 {
+		return chart
+	}
+	chart.MaxX = timeline[len(timeline)-1].Second
+	for _, p := range timeline {
+		for _, name := range names {
+			if v := valueOf(p, name); v > chart.MaxY {
+				chart.MaxY = v
+			}
+		}
+	}
+	maxX, maxY := chart.MaxX, chart.MaxY
+	if maxX == 0 {
+		maxX = 1
+	}
+	if maxY == 0 {
+		maxY = 1
+	}
+
+	plotW, plotH := float64(chartWidth-2*chartMargin), float64(chartHeight-2*chartMargin)
+	for i, name := range names {
+		var points []string
+		for _, p := range timeline {
+			x := chartMargin + plotW*float64(p.Second)/float64(maxX)
+			y := chartMargin + plotH - plotH*float64(valueOf(p, name))/float64(maxY)
+			points = append(points, fmt.Sprintf("%.1f,%.1f", x, y))
+		}
+		chart.Series = append(chart.Series, series{Name: name, Color: chartColors[i%len(chartColors)], Points: strings.Join(points, " ")})
+	}
+	return chart
+}
+
+// newLatencyChart draws the p50, p90 and p99 latencies of every test case side by side.
+func newLatencyChart(tcs []TCSummary) barChart {
+	chart := barChart{Width: chartWidth, Height: chartHeight}
+	if len(tcs) == 0 {
+		return chart
+	}
+
+	var maxLatency int64 = 1
+	for _, tc := range tcs {
+		if tc.Latency.P99 > maxLatency {
+			maxLatency = tc.Latency.P99
+		}
+	}
+
+	plotW, plotH := chartWidth-2*chartMargin, chartHeight-2*chartMargin
+	groupW := plotW / len(tcs)
+	barW := groupW / 4
+	for i, tc := range tcs {
+		for j, v := range []struct {
+			label string
+			value int64
+		}{{"p50", tc.Latency.P50}, {"p90", tc.Latency.P90}, {"p99", tc.Latency.P99}} {
+			h := int(int64(plotH) * v.value / maxLatency)
+			chart.Bars = append(chart.Bars, bar{
+				Label:     fmt.Sprintf("%s %s", tc.Name, v.label),
+				Color:     chartColors[j],
+				X:         chartMargin + i*groupW + j*barW,
+				Y:         chartMargin + plotH - h,
+				W:         barW - 2,
+				H:         h,
+				ValueText: fmt.Sprintf("%dms", v.value),
+			})
+		}
+		chart.Labels = append(chart.Labels, bar{Label: tc.Name, X: chartMargin + i*groupW, Y: chartHeight - chartMargin/2})
+	}
+	return chart
+}
+
+type htmlData struct {
+	*Report
+	Throughput lineChart
+	PerTC      lineChart
+	Latency    barChart
+	OnChain    lineChart
+	TxPool     lineChart
+}
+
+// onChainTimeline merges the offered load and the mined TPS into a timeline, so that they are drawn on the same axes.
+func onChainTimeline(r *Report) []TimelinePoint {
+	points := make(map[int64]*TimelinePoint)
+	get := func(second int64) *TimelinePoint {
+		p, ok := points[second]
+		if !ok {
+			p = &TimelinePoint{Second: second, PerTC: make(map[string]uint64)}
+			points[second] = p
+		}
+		return p
+	}
+	for _, p := range r.Timeline {
+		get(p.Second).PerTC["offered"] = p.Success + p.Failure
+	}
+	for _, b := range r.Blocks {
+		get(b.Second).PerTC["mined"] = uint64(b.TPS + 0.5)
+	}
+
+	var merged []TimelinePoint
+	for _, p := range points {
+		merged = append(merged, *p)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Second < merged[j].Second })
+
+	// A second without a request or a block keeps the previous value instead of dropping to zero.
+	last := make(map[string]uint64)
+	for _, p := range merged {
+		for _, name := range []string{"offered", "mined"} {
+			if v, ok := p.PerTC[name]; ok {
+				last[name] = v
+			} else {
+				p.PerTC[name] = last[name]
+			}
+		}
+	}
+	return merged
+}
+
+// txPoolTimeline converts the txpool samples into a timeline, taking the last sample of every second.
+func txPoolTimeline(r *Report) []TimelinePoint {
+	var timeline []TimelinePoint
+	for _, p := range r.TxPool {
+		point := TimelinePoint{Second: p.Second, PerTC: map[string]uint64{"pending": uint64(p.Pending), "queued": uint64(p.Queued)}}
+		if n := len(timeline); n > 0 && timeline[n-1].Second == p.Second {
+			timeline[n-1] = point
+		} else {
+			timeline = append(timeline, point)
+		}
+	}
+	return timeline
+}
+
+func writeHTML(w io.Writer, r *Report) error {
+	var tcNames []string
+	for _, tc := range r.TestCases {
+		tcNames = append(tcNames, tc.Name)
+	}
+	sort.Strings(tcNames)
+
+	data := htmlData{
+		Report: r,
+		Throughput: newLineChart(r.Timeline, []string{"success", "failure"}, func(p TimelinePoint, name string) uint64 {
+			if name == "success" {
+				return p.Success
+			}
+			return p.Failure
+		}),
+		PerTC: newLineChart(r.Timeline, tcNames, func(p TimelinePoint, name string) uint64 {
+			return p.PerTC[name]
+		}),
+		Latency: newLatencyChart(r.TestCases),
+		OnChain: newLineChart(onChainTimeline(r), []string{"offered", "mined"}, func(p TimelinePoint, name string) uint64 {
+			return p.PerTC[name]
+		}),
+		TxPool: newLineChart(txPoolTimeline(r), []string{"pending", "queued"}, func(p TimelinePoint, name string) uint64 {
+			return p.PerTC[name]
+		}),
+	}
+	return htmlTemplate.Execute(w, data)
+}
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>klayslave report {{.StartTime.Format "2006-01-02 15:04:05"}}</title>
+<style>
+body { font-family: sans-serif; margin: 24px; color: #222; }
+table { border-collapse: collapse; margin-bottom: 24px; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; font-size: 13px; }
+th { background: #f3f3f3; }
+td.num { text-align: right; }
+svg { border: 1px solid #ddd; margin-bottom: 8px; }
+.legend span { margin-right: 16px; font-size: 13px; }
+</style>
+</head>
+<body>
+<h1>klayslave report</h1>
+
+<h2>Run</h2>
+<table>
+<tr><th>Tool version</th><td>{{.Metadata.ToolVersion}}</td></tr>
+<tr><th>Node version</th><td>{{.Metadata.NodeVersion}}</td></tr>
+<tr><th>Chain ID</th><td>{{.Metadata.ChainID}}</td></tr>
+<tr><th>Endpoint</th><td>{{.Metadata.Endpoint}}</td></tr>
+<tr><th>Slave index</th><td>{{.Metadata.SlaveIndex}}</td></tr>
+<tr><th>Host</th><td>{{.Metadata.Hostname}}</td></tr>
+<tr><th>Start</th><td>{{.StartTime.Format "2006-01-02 15:04:05 MST"}}</td></tr>
+<tr><th>End</th><td>{{.EndTime.Format "2006-01-02 15:04:05 MST"}}</td></tr>
+<tr><th>Duration</th><td>{{printf "%.0f" .DurationSec}}s</td></tr>
+</table>
+
+<h2>Scenario</h2>
+<table>
+<tr><th>Test case</th><th>Weight</th></tr>
+{{range .Metadata.Scenario}}<tr><td>{{.Name}}</td><td class="num">{{.Weight}}</td></tr>
+{{end}}</table>
+
+<h2>Test cases</h2>
+<table>
+<tr><th>Test case</th><th>Success</th><th>Failure</th><th>Avg RPS</th><th>Avg (ms)</th><th>Min</th><th>p50</th><th>p90</th><th>p95</th><th>p99</th><th>Max</th></tr>
+{{range .TestCases}}<tr><td>{{.Name}}</td><td class="num">{{.Success}}</td><td class="num">{{.Failure}}</td><td class="num">{{printf "%.2f" .AvgRPS}}</td><td class="num">{{printf "%.1f" .Latency.Avg}}</td><td class="num">{{.Latency.Min}}</td><td class="num">{{.Latency.P50}}</td><td class="num">{{.Latency.P90}}</td><td class="num">{{.Latency.P95}}</td><td class="num">{{.Latency.P99}}</td><td class="num">{{.Latency.Max}}</td></tr>
+{{end}}</table>
+
+{{with .Verification}}<h2>Verification</h2>
+<table>
+<tr><th>Check</th><th>Success</th><th>Failure</th><th>Avg (ms)</th><th>p50</th><th>p95</th><th>p99</th><th>Max</th></tr>
+{{range .}}<tr><td>{{.Name}}</td><td class="num">{{.Success}}</td><td class="num">{{.Failure}}</td><td class="num">{{printf "%.1f" .Latency.Avg}}</td><td class="num">{{.Latency.P50}}</td><td class="num">{{.Latency.P95}}</td><td class="num">{{.Latency.P99}}</td><td class="num">{{.Latency.Max}}</td></tr>
+{{end}}</table>
+{{end}}
+<h2>Throughput (requests/s)</h2>
+{{template "line" .Throughput}}
+<h2>Throughput per test case (requests/s)</h2>
+{{template "line" .PerTC}}
+
+{{with .Capacity}}<h2>Capacity</h2>
+<table>
+<tr><th>Knee rate (requests/s)</th><td class="num">{{printf "%.1f" .KneeRate}}</td></tr>
+<tr><th>Mined TPS at the knee</th><td class="num">{{printf "%.1f" .KneeMinedTPS}}</td></tr>
+<tr><th>Saturated</th><td>{{.Saturated}}</td></tr>
+{{if .BelowStartRate}}<tr><th>Below the start rate</th><td>the start rate violated the SLOs, no knee point was found</td></tr>
+{{end}}
+{{range $name, $value := .SLO}}<tr><th>SLO {{$name}}</th><td>{{$value}}</td></tr>
+{{end}}</table>
+<table>
+<tr><th>Offered</th><th>Achieved</th><th>Mined TPS</th><th>Own mined TPS</th><th>Failure rate</th><th>Inclusion p95 (ms)</th><th>Txpool ratio</th><th>Pass</th><th>Violations</th></tr>
+{{range .Steps}}<tr><td class="num">{{printf "%.1f" .OfferedRate}}</td><td class="num">{{printf "%.1f" .AchievedRate}}</td><td class="num">{{printf "%.1f" .MinedTPS}}</td><td class="num">{{printf "%.1f" .OwnMinedTPS}}</td><td class="num">{{printf "%.4f" .FailureRate}}</td><td class="num">{{.InclusionLatencyMs}}</td><td class="num">{{printf "%.2f" .TxPoolRatio}}</td><td>{{.Pass}}</td><td>{{range .Violations}}{{.}}<br>{{end}}</td></tr>
+{{end}}</table>
+{{end}}
+{{with .Auction}}<h2>Auction</h2>
+<table>
+<tr><th>Test case</th><th>Target tx type</th><th>Bids</th><th>Won</th><th>Win rate</th><th>Avg inclusion (ms)</th><th>Call failed</th><th>Reverted</th><th>Lost</th><th>Not mined</th><th>Dropped</th><th>Accounting errors</th><th>Unchecked</th><th>Bid (kei)</th><th>Fee vault (kei)</th><th>Payback (kei)</th><th>Gas taken (kei)</th><th>Bid tx fee (kei)</th></tr>
+{{range .}}<tr><td>{{.TC}}</td><td>{{.TargetTxType}}</td><td class="num">{{.Bids}}</td><td class="num">{{.Won}}</td><td class="num">{{printf "%.3f" .WinRate}}</td><td class="num">{{printf "%.0f" .AvgInclusionMs}}</td><td class="num">{{.CallFailed}}</td><td class="num">{{.Reverted}}</td><td class="num">{{.Lost}}</td><td class="num">{{.NotMined}}</td><td class="num">{{.Dropped}}</td><td class="num">{{.AccountingErrors}}</td><td class="num">{{.AccountingUnchecked}}</td><td class="num">{{.Bid}}</td><td class="num">{{.FeeVaultNet}}</td><td class="num">{{.Payback}}</td><td class="num">{{.GasTaken}}</td><td class="num">{{.BidTxFee}}</td></tr>
+{{end}}</table>
+{{end}}
+<h2>On-chain</h2>
+<table>
+<tr><th>Blocks</th><th>Txs</th><th>Own txs</th><th>Avg TPS</th><th>Avg gas/s</th><th>Avg block time (s)</th></tr>
+<tr><td class="num">{{.Chain.Blocks}}</td><td class="num">{{.Chain.Txs}}</td><td class="num">{{.Chain.OwnTxs}}</td><td class="num">{{printf "%.1f" .Chain.AvgTPS}}</td><td class="num">{{printf "%.0f" .Chain.AvgGasPerSec}}</td><td class="num">{{printf "%.3f" .Chain.AvgBlockTimeSec}}</td></tr>
+</table>
+<h3>Offered load (requests/s) and mined TPS</h3>
+{{template "line" .OnChain}}
+<h3>Txpool depth</h3>
+{{template "line" .TxPool}}
+
+<h2>Latency percentiles</h2>
+<svg width="{{.Latency.Width}}" height="{{.Latency.Height}}" xmlns="http://www.w3.org/2000/svg">
+{{range .Latency.Bars}}<rect x="{{.X}}" y="{{.Y}}" width="{{.W}}" height="{{.H}}" fill="{{.Color}}"><title>{{.Label}}: {{.ValueText}}</title></rect>
+{{end}}{{range .Latency.Labels}}<text x="{{.X}}" y="{{.Y}}" font-size="11">{{.Label}}</text>
+{{end}}</svg>
+<div class="legend"><span style="color:#1f77b4">■ p50</span><span style="color:#ff7f0e">■ p90</span><span style="color:#2ca02c">■ p99</span></div>
+
+<h2>Failures</h2>
+<table>
+<tr><th>Test case</th><th>Count</th><th>Category</th></tr>
+{{range $tc := .TestCases}}{{range .Failures}}<tr><td>{{$tc.Name}}</td><td class="num">{{.Count}}</td><td>{{.Category}}</td></tr>
+{{end}}{{end}}{{range $tc := .Verification}}{{range .Failures}}<tr><td>{{$tc.Name}}</td><td class="num">{{.Count}}</td><td>{{.Category}}</td></tr>
+{{end}}{{end}}</table>
+
+<h2>Setup</h2>
+<table>
+<tr><th>Step</th><th>Duration (s)</th></tr>
+{{range .Setup}}<tr><td>{{.Name}}</td><td class="num">{{printf "%.1f" .DurationSec}}</td></tr>
+{{end}}</table>
+
+<h2>Flags</h2>
+<table>
+<tr><th>Flag</th><th>Value</th></tr>
+{{range $name, $value := .Metadata.Flags}}<tr><td>{{$name}}</td><td>{{$value}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+{{define "line"}}<svg width="{{.Width}}" height="{{.Height}}" xmlns="http://www.w3.org/2000/svg">
+<line x1="40" y1="200" x2="860" y2="200" stroke="#999"/>
+<line x1="40" y1="40" x2="40" y2="200" stroke="#999"/>
+<text x="4" y="44" font-size="11">{{.MaxY}}</text>
+<text x="4" y="204" font-size="11">0</text>
+<text x="830" y="220" font-size="11">{{.MaxX}}s</text>
+{{range .Series}}<polyline fill="none" stroke="{{.Color}}" stroke-width="1.5" points="{{.Points}}"/>
+{{end}}</svg>
+<div class="legend">{{range .Series}}<span style="color:{{.Color}}">■ {{.Name}}</span>{{end}}</div>
+{{end}}`))
@@ -0,0 +1,544 @@
+// Package report collects the results of a load test run and writes them as JSON and HTML at shutdown.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/myzhan/boomer"
+)
+
+// Metadata describes the environment of the run.
+type Metadata struct {
+	ToolVersion string            `json:"toolVersion"`
+	NodeVersion string            `json:"nodeVersion"`
+	ChainID     string            `json:"chainId"`
+	Endpoint    string            `json:"endpoint"`
+	SlaveIndex  int               `json:"slaveIndex"`
+	Hostname    string            `json:"hostname"`
+	Scenario    []ScenarioTC      `json:"scenario"`
+	Flags       map[string]string `json:"flags"`
+}
+
+// ScenarioTC is a test case of the scenario and its weight.
+type ScenarioTC struct {
+	Name   string `json:"name"`
+	Weight int    `json:"weight"`
+}
+
+// SetupTiming is the elapsed time of a setup step before the load starts.
+type SetupTiming struct {
+	Name        string  `json:"name"`
+	DurationSec float64 `json:"durationSec"`
+}
+
+// LatencySummary holds the latency statistics of a test case in milliseconds.
+type LatencySummary struct {
+	Avg float64 `json:"avg"`
+	Min int64   `json:"min"`
+	P50 int64   `json:"p50"`
+	P90 int64   `json:"p90"`
+	P95 int64   `json:"p95"`
+	P99 int64   `json:"p99"`
+	Max int64   `json:"max"`
+}
+
+// FailureCategory is a group of failures which have the same error message except for the numbers and hashes.
+type FailureCategory struct {
+	Category string `json:"category"`
+	Count    uint64 `json:"count"`
+}
+
+// TCSummary holds the totals of a test case.
+type TCSummary struct {
+	Name     string            `json:"name"`
+	Success  uint64            `json:"success"`
+	Failure  uint64            `json:"failure"`
+	AvgRPS   float64           `json:"avgRps"`
+	Latency  LatencySummary    `json:"latencyMs"`
+	Failures []FailureCategory `json:"failures"`
+}
+
+// TimelinePoint holds the number of requests finished in a second since the load started.
+type TimelinePoint struct {
+	Second  int64             `json:"second"`
+	Success uint64            `json:"success"`
+	Failure uint64            `json:"failure"`
+	PerTC   map[string]uint64 `json:"perTc"`
+}
+
+// BlockPoint holds the on-chain statistics observed by the block monitor when a new block arrives.
+type BlockPoint struct {
+	Second       int64   `json:"second"` // seconds since the load started
+	Number       uint64  `json:"number"`
+	TxCount      int     `json:"txCount"`
+	OwnTxCount   int     `json:"ownTxCount"`
+	GasUsed      uint64  `json:"gasUsed"`
+	BaseFee      string  `json:"baseFee"`
+	BlockTimeSec float64 `json:"blockTimeSec"`
+	TPS          float64 `json:"tps"`       // mined TPS over the shortest window
+	GasPerSec    float64 `json:"gasPerSec"` // gas used per second over the shortest window
+}
+
+// TxPoolPoint holds the depth of the txpool observed by the txpool monitor.
+type TxPoolPoint struct {
+	Second     int64 `json:"second"` // seconds since the load started
+	Pending    int   `json:"pending"`
+	Queued     int   `json:"queued"`
+	OwnPending int   `json:"ownPending"`
+	OwnQueued  int   `json:"ownQueued"`
+}
+
+// ChainSummary holds the on-chain totals of the blocks observed during the load.
+type ChainSummary struct {
+	Blocks          int     `json:"blocks"`
+	Txs             int     `json:"txs"`
+	OwnTxs          int     `json:"ownTxs"`
+	AvgTPS          float64 `json:"avgTps"`
+	AvgGasPerSec    float64 `json:"avgGasPerSec"`
+	AvgBlockTimeSec float64 `json:"avgBlockTimeSec"`
+}
+
+// CapacityStep is the measurement of a step of the capacity search.
+type CapacityStep struct {
+	OfferedRate        float64  `json:"offeredRate"`  // requests/s the step aimed at
+	AchievedRate       float64  `json:"achievedRate"` // requests/s actually finished
+	MinedTPS           float64  `json:"minedTps"`
+	OwnMinedTPS        float64  `json:"ownMinedTps"`
+	FailureRate        float64  `json:"failureRate"`
+	InclusionLatencyMs int64    `json:"inclusionLatencyMs"` // p95 of the probe txs
+	TxPoolRatio        float64  `json:"txpoolRatio"`        // average depth over the capacity of the txpool
+	Pass               bool     `json:"pass"`
+	Violations         []string `json:"violations,omitempty"`
+}
+
+// CapacityResult is the result of the capacity search.
+type CapacityResult struct {
+	SLO map[string]string `json:"slo"`
+	// KneeRate is the highest offered rate which met the SLOs. It is zero if BelowStartRate.
+	KneeRate     float64 `json:"kneeRate"`
+	KneeMinedTPS float64 `json:"kneeMinedTps"`
+	Saturated    bool    `json:"saturated"` // false if the max rate met the SLOs, so the knee may be higher
+	// BelowStartRate is true if even the start rate violated the SLOs, so the search failed without a knee point.
+	BelowStartRate bool           `json:"belowStartRate"`
+	Steps          []CapacityStep `json:"steps"`
+}
+
+// AuctionSummary is the outcome of the bids of an auction TC on a target tx type, followed by the auction verifier.
+// The amounts are the sums over the executed bids in kei.
+type AuctionSummary struct {
+	TC                  string  `json:"tc"`
+	TargetTxType        string  `json:"targetTxType"`
+	Bids                uint64  `json:"bids"`
+	Won                 uint64  `json:"won"`
+	CallFailed          uint64  `json:"callFailed"`
+	Reverted            uint64  `json:"reverted"`
+	Lost                uint64  `json:"lost"`
+	NotMined            uint64  `json:"notMined"`
+	Dropped             uint64  `json:"dropped"`
+	AccountingErrors    uint64  `json:"accountingErrors"`
+	AccountingUnchecked uint64  `json:"accountingUnchecked"`
+	WinRate             float64 `json:"winRate"`
+	AvgInclusionMs      float64 `json:"avgInclusionMs"` // from sending the bid to the target tx being mined
+	Bid                 string  `json:"bid"`
+	FeeVaultNet         string  `json:"feeVaultNet"` // bids kept by the fee vault after the paybacks
+	Payback             string  `json:"payback"`
+	GasTaken            string  `json:"gasTaken"` // gas fee refunded to the proposer from the deposits
+	BidTxFee            string  `json:"bidTxFee"` // gas fee paid by the proposer for the bid txs
+}
+
+// Report is the end-of-run report.
+type Report struct {
+	Metadata    Metadata      `json:"metadata"`
+	StartTime   time.Time     `json:"startTime"`
+	EndTime     time.Time     `json:"endTime"`
+	DurationSec float64       `json:"durationSec"`
+	Setup       []SetupTiming `json:"setup"`
+	TestCases   []TCSummary   `json:"testCases"`
+	// Verification holds the results of the sampled txs checked after mining, which are not counted as the requests of the TCs.
+	Verification []TCSummary      `json:"verification,omitempty"`
+	Timeline     []TimelinePoint  `json:"timeline"`
+	Chain        ChainSummary     `json:"chain"`
+	Blocks       []BlockPoint     `json:"blocks"`
+	TxPool       []TxPoolPoint    `json:"txpool"`
+	Capacity     *CapacityResult  `json:"capacity,omitempty"`
+	Auction      []AuctionSummary `json:"auction,omitempty"`
+}
+
+type tcStat struct {
+	success    uint64
+	failure    uint64
+	latencySum int64
+	latencies  map[int64]uint64 // latency(ms) -> count
+	failures   map[string]uint64
+}
+
+// OnChainRequestType is the request type of the on-chain statistics reported to Locust by the block monitor.
+const OnChainRequestType = "onchain"
+
+// VerifyRequestType is the request type of the receipt verification results of the sampled txs.
+const VerifyRequestType = "verify"
+
+// Collected results. Requests are recorded from the boomer event handlers, so every access is guarded by mu.
+var (
+	mu           sync.Mutex
+	metadata     Metadata
+	setupTimings []SetupTiming
+	startTime    time.Time
+	tcStats      = make(map[string]*tcStat)
+	verifyStats  = make(map[string]*tcStat)
+	timeline     = make(map[int64]*TimelinePoint)
+	blocks       []BlockPoint
+	txPool       []TxPoolPoint
+	capacity     *CapacityResult
+	auction      []AuctionSummary
+
+	variablePattern = regexp.MustCompile(`0x[0-9a-fA-F]+|[0-9]+`)
+)
+
+// SetMetadata sets the metadata of the run.
+func SetMetadata(m Metadata) {
+	mu.Lock()
+	defer mu.Unlock()
+	metadata = m
+}
+
+// StartSetupStep starts timing a setup step. Call the returned function when the step is done.
+func StartSetupStep(name string) func() {
+	start := time.Now()
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		setupTimings = append(setupTimings, SetupTiming{Name: name, DurationSec: time.Since(start).Seconds()})
+	}
+}
+
+// Start starts recording the requests reported to boomer.
+// The load is regarded as started when the master spawns the users, or at the first request if it comes earlier,
+// so that the idle time until then is not counted in the duration.
+func Start() {
+	if err := boomer.Events.Subscribe("boomer:spawn", onSpawn); err != nil {
+		log.Printf("Failed to subscribe boomer:spawn: %v", err)
+	}
+	if err := boomer.Events.Subscribe("request_success", onSuccess); err != nil {
+		log.Printf("Failed to subscribe request_success: %v", err)
+	}
+	if err := boomer.Events.Subscribe("request_failure", onFailure); err != nil {
+		log.Printf("Failed to subscribe request_failure: %v", err)
+	}
+}
+
+// RecordBlock records the statistics of a block observed by the block monitor.
+func RecordBlock(b BlockPoint) {
+	mu.Lock()
+	defer mu.Unlock()
+	if startTime.IsZero() {
+		return // the load has not started yet
+	}
+	b.Second = int64(time.Since(startTime).Seconds())
+	blocks = append(blocks, b)
+}
+
+// SetCapacity sets the result of the capacity search.
+func SetCapacity(result CapacityResult) {
+	mu.Lock()
+	defer mu.Unlock()
+	capacity = &result
+}
+
+// SetAuction sets the latest statistics of the auction verifier.
+func SetAuction(summaries []AuctionSummary) {
+	mu.Lock()
+	defer mu.Unlock()
+	auction = summaries
+}
+
+// RecordTxPool records the depth of the txpool observed by the txpool monitor.
+func RecordTxPool(p TxPoolPoint) {
+	mu.Lock()
+	defer mu.Unlock()
+	if startTime.IsZero() {
+		return // the load has not started yet
+	}
+	p.Second = int64(time.Since(startTime).Seconds())
+	txPool = append(txPool, p)
+}
+
+// tcNameOf strips the endpoint from the request name, e.g. "newValueTransferTC to http://localhost:8551".
+func tcNameOf(name string) string {
+	if idx := strings.Index(name, " to "); idx >= 0 {
+		return name[:idx]
+	}
+	return name
+}
+
+// toMillis converts the response time given to boomer, which is either int64 or float64.
+func toMillis(responseTime interface{}) int64 {
+	switch t := responseTime.(type) {
+	case int64:
+		return t
+	case float64:
+		return int64(t)
+	}
+	return 0
+}
+
+// failureCategoryOf masks the hashes and the numbers of the error message so that similar errors are grouped.
+func failureCategoryOf(exception string) string {
+	category := variablePattern.ReplaceAllStringFunc(exception, func(v string) string {
+		if strings.HasPrefix(v, "0x") {
+			return "0x…"
+		}
+		return "N"
+	})
+	if len(category) > 200 {
+		category = category[:200] + "…"
+	}
+	return category
+}
+
+func getTCStat(stats map[string]*tcStat, name string) *tcStat {
+	stat, ok := stats[name]
+	if !ok {
+		stat = &tcStat{latencies: make(map[int64]uint64), failures: make(map[string]uint64)}
+		stats[name] = stat
+	}
+	return stat
+}
+
+func getTimelinePoint() *TimelinePoint {
+	second := int64(time.Since(startTime).Seconds())
+	point, ok := timeline[second]
+	if !ok {
+		point = &TimelinePoint{Second: second, PerTC: make(map[string]uint64)}
+		timeline[second] = point
+	}
+	return point
+}
+
+// markStarted sets the start time of the load if it is not set yet. It should be called with mu held.
+func markStarted() {
+	if startTime.IsZero() {
+		startTime = time.Now()
+	}
+}
+
+func onSpawn(workers int, spawnRate float64) {
+	mu.Lock()
+	defer mu.Unlock()
+	markStarted()
+}
+
+func onSuccess(requestType string, name string, responseTime interface{}, responseLength int64) {
+	// on-chain statistics are reported to Locust as requests, but they are recorded by RecordBlock
+	if requestType == OnChainRequestType {
+		return
+	}
+	tcName, latency := tcNameOf(name), toMillis(responseTime)
+
+	mu.Lock()
+	defer mu.Unlock()
+	markStarted()
+
+	// the verification results follow the requests already recorded, so they are kept apart from the TC traffic
+	if requestType == VerifyRequestType {
+		getTCStat(verifyStats, tcName).recordSuccess(latency)
+		return
+	}
+	getTCStat(tcStats, tcName).recordSuccess(latency)
+
+	point := getTimelinePoint()
+	point.Success++
+	point.PerTC[tcName]++
+}
+
+func onFailure(requestType string, name string, responseTime interface{}, exception string) {
+	if requestType == OnChainRequestType {
+		return
+	}
+	tcName, latency := tcNameOf(name), toMillis(responseTime)
+
+	mu.Lock()
+	defer mu.Unlock()
+	markStarted()
+
+	if requestType == VerifyRequestType {
+		getTCStat(verifyStats, tcName).recordFailure(latency, exception)
+		return
+	}
+	getTCStat(tcStats, tcName).recordFailure(latency, exception)
+
+	getTimelinePoint().Failure++
+}
+
+func (stat *tcStat) recordSuccess(latency int64) {
+	stat.success++
+	stat.latencySum += latency
+	stat.latencies[latency]++
+}
+
+func (stat *tcStat) recordFailure(latency int64, exception string) {
+	stat.failure++
+	stat.latencySum += latency
+	stat.latencies[latency]++
+	stat.failures[failureCategoryOf(exception)]++
+}
+
+// summarizeLatency calculates the latency percentiles from the latency histogram.
+func summarizeLatency(stat *tcStat) LatencySummary {
+	total := stat.success + stat.failure
+	if total == 0 {
+		return LatencySummary{}
+	}
+
+	keys := make([]int64, 0, len(stat.latencies))
+	for latency := range stat.latencies {
+		keys = append(keys, latency)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	percentile := func(p float64) int64 {
+		target := uint64(float64(total)*p + 0.5)
+		if target == 0 {
+			target = 1
+		}
+		var seen uint64
+		for _, latency := range keys {
+			seen += stat.latencies[latency]
+			if seen >= target {
+				return latency
+			}
+		}
+		return keys[len(keys)-1]
+	}
+
+	return LatencySummary{
+		Avg: float64(stat.latencySum) / float64(total),
+		Min: keys[0],
+		P50: percentile(0.50),
+		P90: percentile(0.90),
+		P95: percentile(0.95),
+		P99: percentile(0.99),
+		Max: keys[len(keys)-1],
+	}
+}
+
+// summarizeTCs creates the summaries of the stats in the name order.
+func summarizeTCs(stats map[string]*tcStat, durationSec float64) []TCSummary {
+	var summaries []TCSummary
+	for name, stat := range stats {
+		summary := TCSummary{
+			Name:    name,
+			Success: stat.success,
+			Failure: stat.failure,
+			Latency: summarizeLatency(stat),
+		}
+		if durationSec > 0 {
+			summary.AvgRPS = float64(stat.success+stat.failure) / durationSec
+		}
+		for category, count := range stat.failures {
+			summary.Failures = append(summary.Failures, FailureCategory{Category: category, Count: count})
+		}
+		sort.Slice(summary.Failures, func(i, j int) bool { return summary.Failures[i].Count > summary.Failures[j].Count })
+		summaries = append(summaries, summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Name < summaries[j].Name })
+	return summaries
+}
+
+// build creates the report from the results collected so far.
+func build() *Report {
+	mu.Lock()
+	defer mu.Unlock()
+
+	endTime, start := time.Now(), startTime
+	if start.IsZero() {
+		start = endTime // the load has not started
+	}
+	r := &Report{
+		Metadata:    metadata,
+		StartTime:   start,
+		EndTime:     endTime,
+		DurationSec: endTime.Sub(start).Seconds(),
+		Setup:       append([]SetupTiming{}, setupTimings...),
+	}
+
+	r.TestCases = summarizeTCs(tcStats, r.DurationSec)
+	r.Verification = summarizeTCs(verifyStats, r.DurationSec)
+
+	for _, point := range timeline {
+		perTC := make(map[string]uint64, len(point.PerTC))
+		for name, count := range point.PerTC {
+			perTC[name] = count
+		}
+		r.Timeline = append(r.Timeline, TimelinePoint{Second: point.Second, Success: point.Success, Failure: point.Failure, PerTC: perTC})
+	}
+	sort.Slice(r.Timeline, func(i, j int) bool { return r.Timeline[i].Second < r.Timeline[j].Second })
+
+	r.Blocks = append([]BlockPoint{}, blocks...)
+	r.Chain = summarizeChain(r.Blocks)
+	r.TxPool = append([]TxPoolPoint{}, txPool...)
+	r.Capacity = capacity
+	r.Auction = append([]AuctionSummary{}, auction...)
+
+	return r
+}
+
+func summarizeChain(points []BlockPoint) ChainSummary {
+	var summary ChainSummary
+	var gasUsed uint64
+	var blockTime float64
+	for _, b := range points {
+		summary.Blocks++
+		summary.Txs += b.TxCount
+		summary.OwnTxs += b.OwnTxCount
+		gasUsed += b.GasUsed
+		blockTime += b.BlockTimeSec
+	}
+	if blockTime > 0 {
+		summary.AvgTPS = float64(summary.Txs) / blockTime
+		summary.AvgGasPerSec = float64(gasUsed) / blockTime
+		summary.AvgBlockTimeSec = blockTime / float64(summary.Blocks)
+	}
+	return summary
+}
+
+// Write writes the report into the given directory as a JSON file and a self-contained HTML file.
+// It returns the paths of the written files.
+func Write(dir string) (string, string, error) {
+	r := build()
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", "", fmt.Errorf("failed to create report directory: %v", err)
+	}
+	baseName := fmt.Sprintf("klayslave-%d-%s", r.Metadata.SlaveIndex, r.StartTime.Format("20060102-150405"))
+
+	jsonPath := filepath.Join(dir, baseName+".json")
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode report: %v", err)
+	}
+	if err := os.WriteFile(jsonPath, data, 0o644); err != nil {
+		return "", "", fmt.Errorf("failed to write report: %v", err)
+	}
+
+	htmlPath := filepath.Join(dir, baseName+".html")
+	f, err := os.Create(htmlPath)
+	if err != nil {
+		return jsonPath, "", fmt.Errorf("failed to write report: %v", err)
+	}
+	defer f.Close()
+	if err := writeHTML(f, r); err != nil {
+		return jsonPath, "", fmt.Errorf("failed to render report: %v", err)
+	}
+
+	return jsonPath, htmlPath, nil
+}
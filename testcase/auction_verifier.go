@@ -0,0 +1,268 @@
+package testcase
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kaiachain/kaia-load-tester/klayslave/account"
+	"github.com/kaiachain/kaia-load-tester/klayslave/report"
+	"github.com/kaiachain/kaia/client"
+	"github.com/kaiachain/kaia/common"
+	"github.com/myzhan/boomer"
+)
+
+// Auction outcome verification related variables
+var (
+	auctionVerifyOnce  sync.Once
+	auctionVerifyQueue chan auctionVerifyRequest
+
+	auctionVerifyWorkers   = 16
+	auctionVerifyQueueSize = 10000
+
+	auctionVaultsMu sync.Mutex
+	auctionVaults   *account.AuctionVaults
+
+	auctionStatsMu sync.Mutex
+	auctionStats   = make(map[auctionStatKey]*auctionStat)
+)
+
+type auctionVerifyRequest struct {
+	tcName            string
+	targetTxType      string
+	targetTxHash      common.Hash
+	searcher          common.Address
+	auctionEntryPoint *account.Account
+	expectRevert      bool // the bid tx of auctionRevertedBidTC should revert
+	sentAt            time.Time
+
+	searcherBids []*account.SearcherBid // the bids of auctionCompetitiveBidTC, whose winner is checked instead of a single searcher
+}
+
+type auctionStatKey struct {
+	tcName       string
+	targetTxType string
+}
+
+type auctionStat struct {
+	bids                uint64
+	won                 uint64 // the bid is executed, even if its call failed
+	callFailed          uint64
+	reverted            uint64
+	lost                uint64 // the target tx is mined without the bid, or with the bid of another searcher
+	notMined            uint64 // the target tx is not mined in time, or the outcome is not read
+	dropped             uint64 // not verified because the queue was full
+	accountingErrors    uint64
+	accountingUnchecked uint64 // the balances of the vaults could not be read
+	inclusionMsSum      int64  // time from sending the bid to the target tx being mined, of the won bids
+	bid                 *big.Int
+	feeVaultNet         *big.Int // bids deposited into the fee vault minus the paybacks
+	payback             *big.Int
+	gasTaken            *big.Int // gas fee taken from the deposits and refunded to the proposer
+	bidTxFee            *big.Int // gas fee paid by the proposer for the bid txs
+}
+
+// startAuctionVerifier starts the background workers which follow the outcome of the bids. It is started once by the first auction TC.
+// The outcome of every bid is published with the request type report.VerifyRequestType, and the statistics per target tx type are
+// logged periodically and set into the report.
+func startAuctionVerifier(endpoint string) {
+	auctionVerifyOnce.Do(func() {
+		auctionVerifyQueue = make(chan auctionVerifyRequest, auctionVerifyQueueSize)
+		for i := 0; i < auctionVerifyWorkers; i++ {
+			cli, err := client.Dial(endpoint)
+			if err != nil {
+				log.Fatalf("Failed to connect the auction verifier to %v: %v", endpoint, err)
+			}
+			go auctionVerifyLoop(cli)
+		}
+		go reportAuctionStats()
+	})
+}
+
+// verifyAuction queues a bid accepted by the bid pool for the verification of its outcome.
+func verifyAuction(req auctionVerifyRequest) {
+	select {
+	case auctionVerifyQueue <- req:
+	default:
+		updateAuctionStat(req.tcName, req.targetTxType, func(stat *auctionStat) { stat.dropped++ })
+	}
+}
+
+func auctionVerifyLoop(cli *client.Client) {
+	for req := range auctionVerifyQueue {
+		if req.searcherBids != nil {
+			checkAuctionWinner(cli, req)
+		} else {
+			verifyAuctionOutcome(cli, req)
+		}
+	}
+}
+
+// verifyAuctionOutcome waits until the target tx is mined, and publishes what the tx after it did with the bid
+// as "<tc> auction won", "<tc> auction lost", "<tc> auction reverted" or "<tc> auction accounting".
+func verifyAuctionOutcome(cli *client.Client, req auctionVerifyRequest) {
+	blockHash, blockNumber, txIndex, err := waitMinedIndex(cli, req.targetTxHash)
+	elapsed := time.Since(req.sentAt).Milliseconds()
+	if err != nil {
+		updateAuctionStat(req.tcName, req.targetTxType, func(stat *auctionStat) { stat.bids++; stat.notMined++ })
+		boomer.Events.Publish("request_failure", report.VerifyRequestType, req.tcName+" auction won", elapsed, fmt.Sprintf("the target tx is not mined: %v", err))
+		return
+	}
+
+	outcome, err := getAuctionBidOutcome(cli, req.auctionEntryPoint, blockHash, blockNumber, txIndex+1)
+	if err != nil {
+		updateAuctionStat(req.tcName, req.targetTxType, func(stat *auctionStat) { stat.bids++; stat.notMined++ })
+		boomer.Events.Publish("request_failure", report.VerifyRequestType, req.tcName+" auction won", elapsed, err.Error())
+		return
+	}
+	recordAuctionOutcome(req.tcName, req.targetTxType, req.searcher, outcome, elapsed)
+
+	won := outcome.BidTx != (common.Hash{}) && outcome.Searcher == req.searcher
+	switch {
+	case !won:
+		boomer.Events.Publish("request_failure", report.VerifyRequestType, req.tcName+" auction lost", elapsed, "the target tx is mined without the bid")
+	case outcome.Reverted && req.expectRevert:
+		boomer.Events.Publish("request_success", report.VerifyRequestType, req.tcName+" auction reverted", elapsed, int64(10))
+	case outcome.Reverted:
+		boomer.Events.Publish("request_failure", report.VerifyRequestType, req.tcName+" auction reverted", elapsed, fmt.Sprintf("the bid tx %v reverted", outcome.BidTx.String()))
+	case req.expectRevert:
+		boomer.Events.Publish("request_failure", report.VerifyRequestType, req.tcName+" auction reverted", elapsed, fmt.Sprintf("the bid tx %v did not revert", outcome.BidTx.String()))
+	case !outcome.Executed:
+		boomer.Events.Publish("request_failure", report.VerifyRequestType, req.tcName+" auction won", elapsed, fmt.Sprintf("the bid tx %v did not use the nonce of the searcher", outcome.BidTx.String()))
+	default:
+		boomer.Events.Publish("request_success", report.VerifyRequestType, req.tcName+" auction won", elapsed, int64(10))
+	}
+
+	if outcome.AccountingErr != nil {
+		boomer.Events.Publish("request_failure", report.VerifyRequestType, req.tcName+" auction accounting", elapsed, outcome.AccountingErr.Error())
+	} else if outcome.AccountingChecked {
+		boomer.Events.Publish("request_success", report.VerifyRequestType, req.tcName+" auction accounting", elapsed, int64(10))
+	}
+}
+
+// getAuctionBidOutcome reads the vaults of the AuctionEntryPoint once, and the outcome of the bid tx at bidTxIndex of the block.
+func getAuctionBidOutcome(cli *client.Client, auctionEntryPoint *account.Account, blockHash common.Hash, blockNumber *big.Int, bidTxIndex uint) (*account.AuctionBidOutcome, error) {
+	auctionVaultsMu.Lock()
+	if auctionVaults == nil {
+		vaults, err := account.GetAuctionVaults(cli, auctionEntryPoint)
+		if err != nil {
+			auctionVaultsMu.Unlock()
+			return nil, err
+		}
+		auctionVaults = &vaults
+	}
+	vaults := *auctionVaults
+	auctionVaultsMu.Unlock()
+
+	return account.GetAuctionBidOutcome(cli, vaults, blockHash, blockNumber, bidTxIndex)
+}
+
+// recordAuctionOutcome adds the outcome of a bid of the searcher into the statistics of the TC and the target tx type.
+func recordAuctionOutcome(tcName, targetTxType string, searcher common.Address, outcome *account.AuctionBidOutcome, elapsed int64) {
+	updateAuctionStat(tcName, targetTxType, func(stat *auctionStat) {
+		stat.bids++
+		if outcome.BidTx == (common.Hash{}) || outcome.Searcher != searcher {
+			stat.lost++
+			return
+		}
+		if outcome.Reverted {
+			stat.reverted++
+			stat.bidTxFee.Add(stat.bidTxFee, outcome.BidTxFee)
+			return
+		}
+		if !outcome.Executed {
+			stat.lost++
+			return
+		}
+		stat.won++
+		stat.inclusionMsSum += elapsed
+		if outcome.CallFailed {
+			stat.callFailed++
+		}
+		stat.bid.Add(stat.bid, outcome.TakenBid)
+		stat.feeVaultNet.Add(stat.feeVaultNet, new(big.Int).Sub(outcome.FeeDeposit, outcome.Payback))
+		stat.payback.Add(stat.payback, outcome.Payback)
+		stat.gasTaken.Add(stat.gasTaken, outcome.TakenGas)
+		stat.bidTxFee.Add(stat.bidTxFee, outcome.BidTxFee)
+		if outcome.AccountingErr != nil {
+			stat.accountingErrors++
+		} else if !outcome.AccountingChecked {
+			stat.accountingUnchecked++
+		}
+	})
+}
+
+// updateAuctionStat updates the statistics and sets them into the report.
+func updateAuctionStat(tcName, targetTxType string, update func(stat *auctionStat)) {
+	auctionStatsMu.Lock()
+	defer auctionStatsMu.Unlock()
+	key := auctionStatKey{tcName: tcName, targetTxType: targetTxType}
+	stat, ok := auctionStats[key]
+	if !ok {
+		stat = &auctionStat{bid: new(big.Int), feeVaultNet: new(big.Int), payback: new(big.Int), gasTaken: new(big.Int), bidTxFee: new(big.Int)}
+		auctionStats[key] = stat
+	}
+	update(stat)
+	report.SetAuction(summarizeAuctionStats())
+}
+
+// summarizeAuctionStats returns the statistics sorted by the TC and the target tx type. auctionStatsMu should be held.
+func summarizeAuctionStats() []report.AuctionSummary {
+	summaries := make([]report.AuctionSummary, 0, len(auctionStats))
+	for key, stat := range auctionStats {
+		summary := report.AuctionSummary{
+			TC:                  key.tcName,
+			TargetTxType:        key.targetTxType,
+			Bids:                stat.bids,
+			Won:                 stat.won,
+			CallFailed:          stat.callFailed,
+			Reverted:            stat.reverted,
+			Lost:                stat.lost,
+			NotMined:            stat.notMined,
+			Dropped:             stat.dropped,
+			AccountingErrors:    stat.accountingErrors,
+			AccountingUnchecked: stat.accountingUnchecked,
+			Bid:                 stat.bid.String(),
+			FeeVaultNet:         stat.feeVaultNet.String(),
+			Payback:             stat.payback.String(),
+			GasTaken:            stat.gasTaken.String(),
+			BidTxFee:            stat.bidTxFee.String(),
+		}
+		if stat.bids > 0 {
+			summary.WinRate = float64(stat.won) / float64(stat.bids)
+		}
+		if stat.won > 0 {
+			summary.AvgInclusionMs = float64(stat.inclusionMsSum) / float64(stat.won)
+		}
+		summaries = append(summaries, summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].TC != summaries[j].TC {
+			return summaries[i].TC < summaries[j].TC
+		}
+		return summaries[i].TargetTxType < summaries[j].TargetTxType
+	})
+	return summaries
+}
+
+// reportAuctionStats periodically logs the win rate and the accounting of the bids per target tx type.
+func reportAuctionStats() {
+	for range time.Tick(10 * time.Second) {
+		auctionStatsMu.Lock()
+		summaries := summarizeAuctionStats()
+		auctionStatsMu.Unlock()
+
+		var lines []string
+		for _, s := range summaries {
+			lines = append(lines, fmt.Sprintf("%s/%s: bids %d, won %d (%.1f%%, avg %.0fms), call failed %d, reverted %d, lost %d, not mined %d, dropped %d, accounting errors %d, bid %s, fee vault %s, payback %s, gas taken %s, bid tx fee %s",
+				s.TC, s.TargetTxType, s.Bids, s.Won, s.WinRate*100, s.AvgInclusionMs, s.CallFailed, s.Reverted, s.Lost, s.NotMined, s.Dropped, s.AccountingErrors, s.Bid, s.FeeVaultNet, s.Payback, s.GasTaken, s.BidTxFee))
+		}
+		if len(lines) > 0 {
+			log.Printf("Auction verifier:\n  %s", strings.Join(lines, "\n  "))
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package testcase
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/networks/rpc"
+)
+
+// Log ledger related variables. The ledger counts the logs which the txs sent by the slave emitted per block,
+// so that getLogsTC can check the number of the returned logs over the blocks it covers.
+var (
+	logLedgerOnce         sync.Once
+	logLedgerMu           sync.Mutex
+	logLedgerSent         map[common.Hash]time.Time // sent txs which are not found in a processed block yet
+	logLedgerCounts       map[uint64]map[logKey]int // logs of the sent txs per processed block
+	logLedgerStart        uint64                    // first block whose txs are all recorded
+	logLedgerEnd          uint64                    // last processed block, the blocks [logLedgerStart, logLedgerEnd] are covered
+	logLedgerMaxWidth     uint64                    // blocks kept, the widest range of getLogsTC
+	logLedgerPollInterval = 100 * time.Millisecond
+)
+
+// logKey is the address, the event signature and the first indexed argument of a log.
+type logKey struct {
+	address common.Address
+	event   common.Hash
+	topic1  common.Hash
+}
+
+// blockReceipt is the subset of the receipt fields returned by getBlockReceipts which the ledger counts.
+type blockReceipt struct {
+	TxHash common.Hash `json:"transactionHash"`
+	Logs   []rpcLog    `json:"logs"`
+}
+
+// startLogLedger starts counting the logs of the txs sent from the next block, and keeps those of the latest maxWidth blocks.
+// It is started once by the first getLogsTC.
+func startLogLedger(endpoint string, maxWidth uint64) {
+	logLedgerOnce.Do(func() {
+		cli, err := rpc.Dial(endpoint)
+		if err != nil {
+			log.Fatalf("Failed to connect the log ledger to %v: %v", endpoint, err)
+		}
+		var head hexutil.Uint64
+		if err := cli.CallContext(context.Background(), &head, "kaia_blockNumber"); err != nil {
+			log.Fatalf("Failed to get the block number for the log ledger: %v", err)
+		}
+
+		logLedgerMu.Lock()
+		logLedgerSent = make(map[common.Hash]time.Time)
+		logLedgerCounts = make(map[uint64]map[logKey]int)
+		logLedgerStart, logLedgerEnd = uint64(head)+1, uint64(head)
+		logLedgerMaxWidth = maxWidth
+		logLedgerMu.Unlock()
+
+		go logLedgerLoop(cli, uint64(head)+1)
+	})
+}
+
+// recordLogTx adds a sent tx into the ledger, if the ledger is started.
+func recordLogTx(hash common.Hash) {
+	logLedgerMu.Lock()
+	defer logLedgerMu.Unlock()
+	if logLedgerSent != nil && hash != (common.Hash{}) {
+		logLedgerSent[hash] = time.Now()
+	}
+}
+
+// logLedgerLoop reads the receipts of every block once the next block is mined, so that the txs mined in it are recorded by then.
+// A block which fails to be read is retried at the next poll, which stops the covered blocks from growing meanwhile.
+func logLedgerLoop(cli *rpc.Client, next uint64) {
+	for range time.Tick(logLedgerPollInterval) {
+		var head hexutil.Uint64
+		if err := cli.CallContext(context.Background(), &head, "kaia_blockNumber"); err != nil {
+			continue
+		}
+		for ; next < uint64(head); next++ {
+			var receipts []blockReceipt
+			if err := cli.CallContext(context.Background(), &receipts, "kaia_getBlockReceipts", hexutil.Uint64(next)); err != nil {
+				break
+			}
+			addLedgerBlock(next, receipts)
+		}
+	}
+}
+
+// addLedgerBlock counts the logs of the sent txs in the block, and forgets the blocks older than the widest range of getLogsTC
+// and the sent txs which are not mined in verifyTimeout.
+func addLedgerBlock(number uint64, receipts []blockReceipt) {
+	logLedgerMu.Lock()
+	defer logLedgerMu.Unlock()
+
+	counts := make(map[logKey]int)
+	for _, receipt := range receipts {
+		if _, ok := logLedgerSent[receipt.TxHash]; !ok {
+			continue
+		}
+		delete(logLedgerSent, receipt.TxHash)
+		for _, l := range receipt.Logs {
+			if len(l.Topics) == 0 {
+				continue
+			}
+			key := logKey{address: l.Address, event: l.Topics[0]}
+			if len(l.Topics) > 1 {
+				key.topic1 = l.Topics[1]
+			}
+			counts[key]++
+		}
+	}
+	logLedgerCounts[number] = counts
+	logLedgerEnd = number
+
+	if number >= logLedgerMaxWidth {
+		delete(logLedgerCounts, number-logLedgerMaxWidth)
+	}
+	for hash, sentAt := range logLedgerSent {
+		if time.Since(sentAt) > verifyTimeout {
+			delete(logLedgerSent, hash)
+		}
+	}
+}
+
+// expectedLogs returns the number of the logs of the sent txs which match the query, and false if the ledger does not cover its block range.
+func expectedLogs(q *logQuery) (int, bool) {
+	logLedgerMu.Lock()
+	defer logLedgerMu.Unlock()
+	if logLedgerCounts == nil || q.address == nil || q.to == 0 || q.from < logLedgerStart || q.to > logLedgerEnd {
+		return 0, false
+	}
+
+	expected := 0
+	for number := q.from; number <= q.to; number++ {
+		for key, count := range logLedgerCounts[number] {
+			if key.address != *q.address {
+				continue
+			}
+			if len(q.topics) > 0 && len(q.topics[0]) > 0 && key.event != q.topics[0][0] {
+				continue
+			}
+			if len(q.topics) > 1 && len(q.topics[1]) > 0 && key.topic1 != q.topics[1][0] {
+				continue
+			}
+			expected += count
+		}
+	}
+	return expected, true
+}
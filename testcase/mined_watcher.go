@@ -0,0 +1,49 @@
+package testcase
+
+import (
+	"log"
+	"sync"
+
+	"github.com/kaiachain/kaia/client"
+)
+
+// Mined tx watcher related variables
+var (
+	minedWatchOnce  sync.Once
+	minedWatchQueue chan func(cli *client.Client)
+
+	minedWatchWorkers   = 16
+	minedWatchQueueSize = 10000
+)
+
+// startMinedWatcher starts the background workers which wait for the txs sent by the TCs to be mined.
+// Every worker owns its client, so that the jobs never use the clients of the pool after the TC freed them.
+// It is started once by the first TC which watches its txs.
+func startMinedWatcher(endpoint string) {
+	minedWatchOnce.Do(func() {
+		minedWatchQueue = make(chan func(cli *client.Client), minedWatchQueueSize)
+		for i := 0; i < minedWatchWorkers; i++ {
+			cli, err := client.Dial(endpoint)
+			if err != nil {
+				log.Fatalf("Failed to connect the mined tx watcher to %v: %v", endpoint, err)
+			}
+			go minedWatchLoop(cli)
+		}
+	})
+}
+
+// watchMined queues the job to a worker of the mined tx watcher. It returns false if the queue is full.
+func watchMined(job func(cli *client.Client)) bool {
+	select {
+	case minedWatchQueue <- job:
+		return true
+	default:
+		return false
+	}
+}
+
+func minedWatchLoop(cli *client.Client) {
+	for job := range minedWatchQueue {
+		job(cli)
+	}
+}
@@ -0,0 +1,130 @@
+package testcase
+
+import (
+	"log"
+	"math/big"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kaiachain/kaia-load-tester/klayslave/account"
+	"github.com/kaiachain/kaia/client"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/params"
+	"github.com/myzhan/boomer"
+)
+
+// Account creation related variables
+var (
+	newAccountKeyType = account.AccountKeyTypeLegacy
+	newAccountValue   = new(big.Int).Mul(big.NewInt(10), big.NewInt(params.KAIA))
+	reuseNewAccounts  = false
+
+	// createdAccounts keeps the latest maxCreatedAccounts accounts created by newAccountCreationTC,
+	// so that createdAccountValueTransferTC can send from them without the set growing during a long run.
+	createdAccounts    = account.NewAccountSet(nil)
+	maxCreatedAccounts = 10000
+
+	nCreatedAccounts      uint64
+	stateGrowthReportOnce sync.Once
+)
+
+// SetAccountCreationConfig sets the key type and the initial balance of the accounts created by newAccountCreationTC.
+// If reuse is true, the created accounts are added to the account set used by createdAccountValueTransferTC.
+func SetAccountCreationConfig(keyType account.AccountKeyType, value *big.Int, reuse bool) {
+	newAccountKeyType = keyType
+	newAccountValue = value
+	reuseNewAccounts = reuse
+}
+
+// GetNumCreatedAccounts returns the number of accounts created by newAccountCreationTC so far.
+func GetNumCreatedAccounts() uint64 {
+	return atomic.LoadUint64(&nCreatedAccounts)
+}
+
+// reportStateGrowth periodically logs how many accounts and storage slots have been added to the state
+// by newAccountCreationTC and the state growth TCs.
+func reportStateGrowth() {
+	var last, lastSlots uint64
+	for range time.Tick(10 * time.Second) {
+		total := GetNumCreatedAccounts() + atomic.LoadUint64(&stateGrowthAccounts)
+		slots := atomic.LoadUint64(&stateGrowthSlots)
+		log.Printf("State growth: %d new account(s) created in total, %d in the last 10s (%.1f accounts/s), key type = %v, %d new storage slot(s) in total (%.1f slots/s)",
+			total, total-last, float64(total-last)/10, newAccountKeyType, slots, float64(slots-lastSlots)/10)
+		last, lastSlots = total, slots
+	}
+}
+
+// RunNewAccountCreationTC creates a closure which onboards a brand-new account per call.
+// A created account is reused only after its funding tx is mined, so that createdAccountValueTransferTC never sends from an unfunded account.
+func RunNewAccountCreationTC(config *TCConfig) func() {
+	stateGrowthReportOnce.Do(func() { go reportStateGrowth() })
+	if reuseNewAccounts && newAccountKeyType == account.AccountKeyTypeLegacy {
+		startMinedWatcher(config.EndPoint)
+	}
+
+	return func() {
+		cli := config.CliPool.Alloc().(*client.Client)
+		defer config.CliPool.Free(cli)
+
+		from := config.AccGrp.GetAccountRandomly()
+
+		start := boomer.Now()
+		newAcc, fundingHash, err := from.CreateNewAccount(cli, newAccountKeyType, newAccountValue)
+		elapsed := boomer.Now() - start
+
+		if err == nil {
+			atomic.AddUint64(&nCreatedAccounts, 1)
+			if reuseNewAccounts {
+				reuseCreatedAccount(newAcc, fundingHash)
+			}
+			boomer.Events.Publish("request_success", "http", config.Name+" to "+config.EndPoint, elapsed, int64(10))
+		} else {
+			boomer.Events.Publish("request_failure", "http", config.Name+" to "+config.EndPoint, elapsed, err.Error())
+		}
+	}
+}
+
+// reuseCreatedAccount adds the created account to the set of createdAccountValueTransferTC once its funding tx is mined.
+// The legacy accounts are returned before it is mined, so they are waited for by the mined tx watcher.
+// An account is not reused if the watcher queue is full or the funding tx is not mined in time.
+func reuseCreatedAccount(acc *account.Account, fundingHash common.Hash) {
+	if newAccountKeyType != account.AccountKeyTypeLegacy {
+		createdAccounts.AddRing(acc, maxCreatedAccounts)
+		return
+	}
+	watchMined(func(cli *client.Client) {
+		if _, err := waitMined(cli, fundingHash); err == nil {
+			createdAccounts.AddRing(acc, maxCreatedAccounts)
+		}
+	})
+}
+
+// RunCreatedAccountValueTransferTC sends value transfers from the accounts created by newAccountCreationTC.
+// Until any account is created, it sends from the signed tx accounts instead.
+func RunCreatedAccountValueTransferTC(config *TCConfig) func() {
+	return func() {
+		cli := config.CliPool.Alloc().(*client.Client)
+		defer config.CliPool.Free(cli)
+
+		accs := config.AccGrp
+		if createdAccounts.LockedLen() > 0 {
+			accs = createdAccounts
+		}
+		from := accs.GetAccountRandomly()
+		to := accs.GetAccountRandomly()
+		value := big.NewInt(int64(rand.Int() % 3))
+
+		start := boomer.Now()
+		hash, _, err := from.TransferNewValueTransferTx(cli, to, value)
+		elapsed := boomer.Now() - start
+
+		if err == nil {
+			onTxSent(config.Name, hash)
+			boomer.Events.Publish("request_success", "http", config.Name+" to "+config.EndPoint, elapsed, int64(10))
+		} else {
+			boomer.Events.Publish("request_failure", "http", config.Name+" to "+config.EndPoint, elapsed, err.Error())
+		}
+	}
+}
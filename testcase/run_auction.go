@@ -1,8 +1,10 @@
 package testcase
 
 import (
+	"log"
 	"math/big"
 	"math/rand"
+	"time"
 
 	"github.com/kaiachain/kaia-load-tester/klayslave/account"
 	"github.com/kaiachain/kaia/client"
@@ -13,8 +15,11 @@ import (
 // AuctionTxFunc represents an auction transaction function signature
 type AuctionTxFunc = func(*account.Account, *client.Client, *account.Account, *account.Account, string) (common.Hash, common.Hash, *big.Int, error)
 
-// RunBaseWithAuction creates a closure that executes an auction test case with common logic
-func RunBaseWithAuction(config *TCConfig, auctionTxFunc AuctionTxFunc) func() {
+// RunBaseWithAuction creates a closure that executes an auction test case with common logic.
+// The send of the bid is published as "<tc> to <endpoint>", and the outcome of an accepted bid is followed by the auction verifier.
+// expectRevert is true if the bid tx should revert.
+func RunBaseWithAuction(config *TCConfig, auctionTxFunc AuctionTxFunc, expectRevert bool) func() {
+	startAuctionVerifier(config.EndPoint)
 	return func() {
 		cli := config.CliPool.Alloc().(*client.Client)
 		defer config.CliPool.Free(cli)
@@ -28,28 +33,52 @@ func RunBaseWithAuction(config *TCConfig, auctionTxFunc AuctionTxFunc) func() {
 		// Select a targetTxType randomly from the list
 		targetTxTypeKey := config.AuctionTargetTxTypeList[rand.Int()%len(config.AuctionTargetTxTypeList)]
 
+		sentAt := time.Now()
 		start := boomer.Now()
-		_, _, _, err := auctionTxFunc(from, cli, auctionEntryPoint, targetContract, targetTxTypeKey)
+		targetTxHash, _, _, err := auctionTxFunc(from, cli, auctionEntryPoint, targetContract, targetTxTypeKey)
 		elapsed := boomer.Now() - start
 
-		if err == nil {
-			boomer.Events.Publish("request_success", "http", config.Name+" to "+config.EndPoint, elapsed, int64(10))
-		} else {
+		if err != nil {
 			boomer.Events.Publish("request_failure", "http", config.Name+" to "+config.EndPoint, elapsed, err.Error())
+			return
 		}
+		boomer.Events.Publish("request_success", "http", config.Name+" to "+config.EndPoint, elapsed, int64(10))
+		recordLogTx(targetTxHash)
+
+		verifyAuction(auctionVerifyRequest{
+			tcName:            config.Name,
+			targetTxType:      targetTxTypeKey,
+			targetTxHash:      targetTxHash,
+			searcher:          from.GetAddress(),
+			auctionEntryPoint: auctionEntryPoint,
+			expectRevert:      expectRevert,
+			sentAt:            sentAt,
+		})
 	}
 }
 
 func RunAuctionBidTC(config *TCConfig) func() {
+	bidParams := account.AuctionBidParams{
+		Bid:          new(big.Int).SetUint64(config.Uint64Param("bid")),
+		Blocks:       config.IntParam("blocks"),
+		CallGasLimit: config.Uint64Param("callGasLimit"),
+	}
+	if bidParams.Blocks <= 0 {
+		log.Fatalf("%v.blocks should be positive: %v", config.Name, bidParams.Blocks)
+	}
 	auctionTxFunc := func(from *account.Account, cli *client.Client, auctionEntryPoint, targetContract *account.Account, targetTxTypeKey string) (common.Hash, common.Hash, *big.Int, error) {
-		return from.AuctionBid(cli, auctionEntryPoint, targetContract, targetTxTypeKey)
+		return from.AuctionBid(cli, auctionEntryPoint, targetContract, targetTxTypeKey, bidParams)
 	}
-	return RunBaseWithAuction(config, auctionTxFunc)
+	return RunBaseWithAuction(config, auctionTxFunc, false)
 }
 
 func RunAuctionRevertedBidTC(config *TCConfig) func() {
+	bidParams := account.AuctionBidParams{
+		Bid:          new(big.Int).SetUint64(config.Uint64Param("bid")),
+		CallGasLimit: config.Uint64Param("callGasLimit"),
+	}
 	auctionTxFunc := func(from *account.Account, cli *client.Client, auctionEntryPoint, targetContract *account.Account, targetTxTypeKey string) (common.Hash, common.Hash, *big.Int, error) {
-		return from.AuctionRevertedBid(cli, auctionEntryPoint, targetContract, targetTxTypeKey)
+		return from.AuctionRevertedBid(cli, auctionEntryPoint, targetContract, targetTxTypeKey, bidParams)
 	}
-	return RunBaseWithAuction(config, auctionTxFunc)
+	return RunBaseWithAuction(config, auctionTxFunc, true)
 }
@@ -0,0 +1,265 @@
+package testcase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"math/rand"
+	"time"
+
+	"github.com/kaiachain/kaia-load-tester/klayslave/account"
+	"github.com/kaiachain/kaia-load-tester/klayslave/report"
+	"github.com/kaiachain/kaia/client"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/kaiax/auction"
+	"github.com/myzhan/boomer"
+)
+
+const (
+	// maxAuctionFutureBlocks is the number of the future blocks which the bid pool accepts a bid for.
+	maxAuctionFutureBlocks = 2
+
+	auctionBlockPollInterval = 50 * time.Millisecond
+)
+
+// bidDistributions generate the bids of n searchers from [minBid, maxBid].
+var bidDistributions = map[string]func(n int, minBid, maxBid uint64) []*big.Int{
+	// uniform picks every bid randomly.
+	"uniform": func(n int, minBid, maxBid uint64) []*big.Int {
+		bids := make([]*big.Int, n)
+		for i := range bids {
+			bids[i] = new(big.Int).SetUint64(minBid + uint64(rand.Int63n(int64(maxBid-minBid+1))))
+		}
+		return bids
+	},
+	// equal bids maxBid by every searcher, so the first bid in the bid pool wins.
+	"equal": func(n int, _, maxBid uint64) []*big.Int {
+		bids := make([]*big.Int, n)
+		for i := range bids {
+			bids[i] = new(big.Int).SetUint64(maxBid)
+		}
+		return bids
+	},
+	// spread bids evenly spaced amounts from minBid to maxBid in a random order of the searchers.
+	"spread": func(n int, minBid, maxBid uint64) []*big.Int {
+		bids := make([]*big.Int, n)
+		for i, j := range rand.Perm(n) {
+			bids[i] = new(big.Int).SetUint64(minBid)
+			if n > 1 {
+				bids[i].Add(bids[i], new(big.Int).SetUint64((maxBid-minBid)*uint64(j)/uint64(n-1)))
+			}
+		}
+		return bids
+	},
+}
+
+// RunAuctionCompetitiveBidTC creates a closure in which several searchers bid on the same target tx for the same blocks.
+// It waits for a new block and bidDelayMs, and then every searcher bids after a random delay up to bidJitterMs.
+// Every bid of a searcher is published as "<tc> bid", or "<tc> outbid" if the bid pool has a higher or an earlier equal bid,
+// and the winner found by the AuctionEntryPoint events after the target tx is mined as "<tc> won by bid rank <rank>".
+// The outcome is also added into the statistics of the auction verifier.
+func RunAuctionCompetitiveBidTC(config *TCConfig) func() {
+	numSearchers := config.IntParam("searchers")
+	if numSearchers < 2 || numSearchers >= config.AccGrp.Len() {
+		log.Fatalf("%v.searchers should be at least 2 and less than the test accounts %v: %v", config.Name, config.AccGrp.Len(), numSearchers)
+	}
+	minBid, maxBid := config.Uint64Param("minBid"), config.Uint64Param("maxBid")
+	if minBid == 0 || maxBid < minBid {
+		log.Fatalf("%v bids should be 0 < minBid <= maxBid: minBid %v, maxBid %v", config.Name, minBid, maxBid)
+	}
+	genBids, ok := bidDistributions[config.StringParam("bidDistribution")]
+	if !ok {
+		log.Fatalf("%v.bidDistribution should be uniform, equal or spread: %v", config.Name, config.StringParam("bidDistribution"))
+	}
+	blocks := config.IntParam("blocks")
+	if blocks < 1 || blocks > maxAuctionFutureBlocks {
+		log.Fatalf("%v.blocks should be between 1 and %v: %v", config.Name, maxAuctionFutureBlocks, blocks)
+	}
+	bidDelayMs, bidJitterMs := config.IntParam("bidDelayMs"), config.IntParam("bidJitterMs")
+	if bidDelayMs < 0 || bidJitterMs < 0 {
+		log.Fatalf("%v bid timing should not be negative: bidDelayMs %v, bidJitterMs %v", config.Name, bidDelayMs, bidJitterMs)
+	}
+	callGasLimit := config.Uint64Param("callGasLimit")
+	startAuctionVerifier(config.EndPoint)
+
+	return func() {
+		cli := config.CliPool.Alloc().(*client.Client)
+		defer config.CliPool.Free(cli)
+
+		// Use round robin to avoid the same account being used too often
+		accs := config.AccGrp.GetAccountsRoundRobin(numSearchers + 1)
+		from, searchers := accs[0], accs[1:]
+
+		auctionEntryPoint := config.SmartContractAccounts[account.ContractAuctionEntryPoint]
+		targetContract := config.SmartContractAccounts[account.ContractCounterForTestAuction]
+
+		// Select a targetTxType randomly from the list
+		targetTxTypeKey := config.AuctionTargetTxTypeList[rand.Int()%len(config.AuctionTargetTxTypeList)]
+
+		blockNumber, err := waitNextBlock(cli)
+		if err != nil {
+			boomer.Events.Publish("request_failure", "http", config.Name+" to "+config.EndPoint, int64(0), err.Error())
+			return
+		}
+		time.Sleep(time.Duration(bidDelayMs) * time.Millisecond)
+
+		bidParams := account.CompetitiveBidParams{
+			Bids:         genBids(numSearchers, minBid, maxBid),
+			Blocks:       blocks,
+			CallGasLimit: callGasLimit,
+			Jitter:       time.Duration(bidJitterMs) * time.Millisecond,
+		}
+
+		start := boomer.Now()
+		targetTx, searcherBids, err := from.AuctionCompetitiveBids(cli, auctionEntryPoint, targetContract, targetTxTypeKey, searchers, blockNumber, bidParams)
+		elapsed := boomer.Now() - start
+
+		for _, searcherBid := range searcherBids {
+			bidElapsed := searcherBid.Elapsed.Milliseconds()
+			switch {
+			case searcherBid.Accepted:
+				boomer.Events.Publish("request_success", "http", config.Name+" bid", bidElapsed, int64(10))
+			case searcherBid.Err.Error() == auction.ErrLowBid.Error():
+				boomer.Events.Publish("request_success", "http", config.Name+" outbid", bidElapsed, int64(10))
+			default:
+				boomer.Events.Publish("request_failure", "http", config.Name+" bid", bidElapsed, searcherBid.Err.Error())
+			}
+		}
+
+		if err != nil {
+			boomer.Events.Publish("request_failure", "http", config.Name+" to "+config.EndPoint, elapsed, err.Error())
+			return
+		}
+		boomer.Events.Publish("request_success", "http", config.Name+" to "+config.EndPoint, elapsed, int64(10))
+
+		recordLogTx(targetTx.Hash())
+
+		sentAt := searcherBids[0].SentAt
+		for _, searcherBid := range searcherBids {
+			if searcherBid.SentAt.Before(sentAt) {
+				sentAt = searcherBid.SentAt
+			}
+		}
+		verifyAuction(auctionVerifyRequest{
+			tcName:            config.Name,
+			targetTxType:      targetTxTypeKey,
+			targetTxHash:      targetTx.Hash(),
+			auctionEntryPoint: auctionEntryPoint,
+			sentAt:            sentAt,
+			searcherBids:      searcherBids,
+		})
+	}
+}
+
+// checkAuctionWinner waits until the target tx of the competitive bids is mined, and publishes the rank of the winning bid among the searchers.
+// The winner should have the highest of the bids accepted by the bid pool. It is run by the workers of the auction verifier,
+// and publishes with the request type report.VerifyRequestType as they do.
+func checkAuctionWinner(cli *client.Client, req auctionVerifyRequest) {
+	name := req.tcName + " winner"
+	searcherBids := req.searcherBids
+
+	blockHash, blockNumber, txIndex, err := waitMinedIndex(cli, req.targetTxHash)
+	if err != nil {
+		updateAuctionStat(req.tcName, req.targetTxType, func(stat *auctionStat) { stat.bids++; stat.notMined++ })
+		boomer.Events.Publish("request_failure", report.VerifyRequestType, name, time.Since(req.sentAt).Milliseconds(), fmt.Sprintf("the target tx is not mined: %v", err))
+		return
+	}
+	elapsed := time.Since(req.sentAt).Milliseconds()
+
+	outcome, err := getAuctionBidOutcome(cli, req.auctionEntryPoint, blockHash, blockNumber, txIndex+1)
+	if err != nil {
+		updateAuctionStat(req.tcName, req.targetTxType, func(stat *auctionStat) { stat.bids++; stat.notMined++ })
+		boomer.Events.Publish("request_failure", report.VerifyRequestType, name, elapsed, err.Error())
+		return
+	}
+
+	var winnerBid *account.SearcherBid
+	for _, searcherBid := range searcherBids {
+		if outcome.Executed && searcherBid.Searcher.GetAddress() == outcome.Searcher {
+			winnerBid = searcherBid
+		}
+	}
+	winner := common.Address{}
+	if winnerBid != nil {
+		winner = outcome.Searcher
+	}
+	recordAuctionOutcome(req.tcName, req.targetTxType, winner, outcome, elapsed)
+
+	if !outcome.Executed {
+		boomer.Events.Publish("request_failure", report.VerifyRequestType, name, elapsed, "the target tx is mined without an executed bid")
+		return
+	}
+
+	highestAccepted := new(big.Int)
+	for _, searcherBid := range searcherBids {
+		if searcherBid.Accepted && searcherBid.Bid.Cmp(highestAccepted) > 0 {
+			highestAccepted = searcherBid.Bid
+		}
+	}
+	if winnerBid == nil {
+		boomer.Events.Publish("request_failure", report.VerifyRequestType, name, elapsed, fmt.Sprintf("the winner %v is not a searcher of the target tx", outcome.Searcher.String()))
+		return
+	}
+
+	// The rank is 1 for the highest bid, shared by the equal bids.
+	rank := 1
+	for _, searcherBid := range searcherBids {
+		if searcherBid.Bid.Cmp(winnerBid.Bid) > 0 {
+			rank++
+		}
+	}
+	name = fmt.Sprintf("%v won by bid rank %d", req.tcName, rank)
+	if winnerBid.Bid.Cmp(highestAccepted) != 0 {
+		boomer.Events.Publish("request_failure", report.VerifyRequestType, name, elapsed, fmt.Sprintf("the bid %v won over the highest accepted bid %v", winnerBid.Bid, highestAccepted))
+		return
+	}
+	boomer.Events.Publish("request_success", report.VerifyRequestType, name, elapsed, int64(10))
+}
+
+// waitNextBlock waits until a new block is mined and returns its number.
+func waitNextBlock(cli *client.Client) (*big.Int, error) {
+	current, err := cli.BlockNumber(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	for time.Since(start) < verifyTimeout {
+		time.Sleep(auctionBlockPollInterval)
+		num, err := cli.BlockNumber(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		if num.Cmp(current) > 0 {
+			return num, nil
+		}
+	}
+	return nil, fmt.Errorf("no block is mined in %v", verifyTimeout)
+}
+
+// waitMinedIndex waits until the tx is mined and returns the hash and the number of its block and its index in the block.
+func waitMinedIndex(cli *client.Client, hash common.Hash) (common.Hash, *big.Int, uint, error) {
+	start := time.Now()
+	for {
+		receipt, err := cli.TransactionReceiptRpcOutput(context.Background(), hash)
+		if err == nil && receipt != nil {
+			blockHash, _ := receipt["blockHash"].(string)
+			number, _ := receipt["blockNumber"].(string)
+			blockNumber, err := hexutil.DecodeBig(number)
+			if err != nil {
+				return common.Hash{}, nil, 0, fmt.Errorf("invalid blockNumber of the receipt: %v", err)
+			}
+			index, _ := receipt["transactionIndex"].(string)
+			txIndex, err := hexutil.DecodeUint64(index)
+			if err != nil {
+				return common.Hash{}, nil, 0, fmt.Errorf("invalid transactionIndex of the receipt: %v", err)
+			}
+			return common.HexToHash(blockHash), blockNumber, uint(txIndex), nil
+		}
+		if time.Since(start) > verifyTimeout {
+			return common.Hash{}, nil, 0, fmt.Errorf("tx is not mined in %v", verifyTimeout)
+		}
+		time.Sleep(verifyPollInterval)
+	}
+}
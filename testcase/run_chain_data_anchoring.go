@@ -0,0 +1,126 @@
+package testcase
+
+import (
+	"context"
+	"crypto/rand"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/kaiachain/kaia-load-tester/klayslave/account"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/client"
+	"github.com/kaiachain/kaia/rlp"
+)
+
+// Formats of the data anchored by the chain data anchoring TCs
+const (
+	AnchoringFormatType0 = "type0" // AnchoringDataType0 of a recent block, as a service chain anchors it
+	AnchoringFormatRaw   = "raw"   // random bytes of AnchoringConfig.DataSize
+)
+
+// AnchoringConfig configures the data anchored by the chain data anchoring TCs.
+type AnchoringConfig struct {
+	Format   string // type0 or raw
+	DataSize int    // bytes of the raw data
+}
+
+// Parameters of the chain data anchoring TCs
+var (
+	anchoringFormatParam   = TCParam{Name: "format", Default: AnchoringFormatType0, Usage: "format of the anchored data: type0 (AnchoringDataType0 of the latest block) or raw (random bytes of dataSize)"}
+	anchoringDataSizeParam = TCParam{Name: "dataSize", Default: 256, Usage: "bytes of the anchored data with the raw format"}
+)
+
+// anchoringType0 caches the type0 data of the latest block, which is refreshed once a second.
+var anchoringType0 struct {
+	mu        sync.Mutex
+	data      []byte
+	updatedAt time.Time
+}
+
+// newAnchoringConfig reads the configuration of a chain data anchoring TC from its parameters.
+func newAnchoringConfig(config *TCConfig) AnchoringConfig {
+	ac := AnchoringConfig{
+		Format:   config.StringParam(anchoringFormatParam.Name),
+		DataSize: config.IntParam(anchoringDataSizeParam.Name),
+	}
+	if ac.Format != AnchoringFormatType0 && ac.Format != AnchoringFormatRaw {
+		log.Fatalf("%v.format should be one of %v and %v: %v", config.Name, AnchoringFormatType0, AnchoringFormatRaw, ac.Format)
+	} else if ac.DataSize <= 0 || ac.DataSize > 100*1024 {
+		// the txpool rejects a tx larger than 128KB
+		log.Fatalf("%v.dataSize should be between 1 and 102400: %v", config.Name, ac.DataSize)
+	}
+	return ac
+}
+
+// anchoringData returns the data to be anchored in the configured format.
+func anchoringData(cli *client.Client, ac AnchoringConfig) ([]byte, error) {
+	if ac.Format == AnchoringFormatRaw {
+		data := make([]byte, ac.DataSize)
+		rand.Read(data)
+		return data, nil
+	}
+
+	anchoringType0.mu.Lock()
+	defer anchoringType0.mu.Unlock()
+	if anchoringType0.data != nil && time.Since(anchoringType0.updatedAt) < time.Second {
+		return anchoringType0.data, nil
+	}
+
+	header, err := cli.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+	anchored, err := types.NewAnchoringDataType0(types.NewBlockWithHeader(header), 1, 0)
+	if err != nil {
+		log.Fatalf("Failed to make the anchoring data: %v", err)
+	}
+	data, err := rlp.EncodeToBytes(anchored)
+	if err != nil {
+		log.Fatalf("Failed to encode the anchoring data: %v", err)
+	}
+	anchoringType0.data, anchoringType0.updatedAt = data, time.Now()
+	return data, nil
+}
+
+// RunNewChainDataAnchoringTC creates a closure for chain data anchoring test case.
+func RunNewChainDataAnchoringTC(config *TCConfig) func() {
+	ac := newAnchoringConfig(config)
+	txFunc := func(cli *client.Client, from *account.Account, to *account.Account, value *big.Int) (interface{}, *big.Int, error) {
+		data, err := anchoringData(cli, ac)
+		if err != nil {
+			return nil, nil, err
+		}
+		return from.TransferNewChainDataAnchoringTx(cli, data)
+	}
+	return RunBaseValueTransfer(config, txFunc)
+}
+
+// RunNewFeeDelegatedChainDataAnchoringTC creates a closure for fee delegated chain data anchoring test case.
+// The fee is paid by the receiver account picked by the base.
+func RunNewFeeDelegatedChainDataAnchoringTC(config *TCConfig) func() {
+	ac := newAnchoringConfig(config)
+	txFunc := func(cli *client.Client, from *account.Account, to *account.Account, value *big.Int) (interface{}, *big.Int, error) {
+		data, err := anchoringData(cli, ac)
+		if err != nil {
+			return nil, nil, err
+		}
+		return from.TransferNewFeeDelegatedChainDataAnchoringTx(cli, to, data)
+	}
+	return RunBaseValueTransfer(config, txFunc)
+}
+
+// RunNewFeeDelegatedChainDataAnchoringWithRatioTC creates a closure for fee delegated chain data anchoring with ratio test case.
+func RunNewFeeDelegatedChainDataAnchoringWithRatioTC(config *TCConfig) func() {
+	ac := newAnchoringConfig(config)
+	feeRatio := config.feeRatio()
+	txFunc := func(cli *client.Client, from *account.Account, to *account.Account, value *big.Int) (interface{}, *big.Int, error) {
+		data, err := anchoringData(cli, ac)
+		if err != nil {
+			return nil, nil, err
+		}
+		return from.TransferNewFeeDelegatedChainDataAnchoringWithRatioTx(cli, to, data, feeRatio)
+	}
+	return RunBaseValueTransfer(config, txFunc)
+}
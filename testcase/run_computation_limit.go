@@ -0,0 +1,169 @@
+package testcase
+
+import (
+	"context"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/kaiachain/kaia-load-tester/klayslave/account"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/client"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/networks/rpc"
+	"github.com/kaiachain/kaia/params"
+)
+
+// Metrics which computationLimitTC targets
+const (
+	LimitMetricComputation = "computation"
+	LimitMetricGas         = "gas"
+)
+
+// computationLimitGas is the built-in gas limit of the txs of computationLimitTC, that of TransferNewSmartContractExecutionTx.
+const computationLimitGas = 5000000
+
+// ComputationLimitConfig configures the cost of every tx of computationLimitTC.
+type ComputationLimitConfig struct {
+	Metric   string // computation or gas
+	Target   uint64 // computation cost or gas per tx, 0 is 90% of the per-tx limit of the metric
+	GasLimit uint64 // gas limit of the txs
+}
+
+// newComputationLimitConfig reads the configuration of computationLimitTC from its parameters.
+func newComputationLimitConfig(config *TCConfig) ComputationLimitConfig {
+	cl := ComputationLimitConfig{
+		Metric:   config.StringParam("metric"),
+		Target:   config.Uint64Param("target"),
+		GasLimit: config.Uint64Param(gasLimitParam.Name),
+	}
+	if cl.Metric != LimitMetricComputation && cl.Metric != LimitMetricGas {
+		log.Fatalf("%v.metric should be one of %v and %v: %v", config.Name, LimitMetricComputation, LimitMetricGas, cl.Metric)
+	}
+	if cl.GasLimit == 0 {
+		cl.GasLimit = computationLimitGas
+	}
+	return cl
+}
+
+// computationLimitTarget returns the target of the configured metric.
+func computationLimitTarget(cl ComputationLimitConfig) uint64 {
+	if cl.Target != 0 {
+		return cl.Target
+	}
+	if cl.Metric == LimitMetricGas {
+		return cl.GasLimit * 9 / 10
+	}
+	return params.OpcodeComputationCostLimitCancun * 9 / 10
+}
+
+// estimateLoopCost returns the gas and the computation cost of a call of the loop contract with the iterations, estimated by the node.
+func estimateLoopCost(rpcCli *rpc.Client, from, contract common.Address, iterations int64) (uint64, uint64) {
+	args := map[string]interface{}{
+		"from": from,
+		"to":   contract,
+		"data": hexutil.Bytes(account.TestContractInfos[account.ContractComputationLoop].GenData(contract, big.NewInt(iterations))),
+	}
+	var gas, computationCost hexutil.Uint64
+	if err := rpcCli.CallContext(context.Background(), &gas, "kaia_estimateGas", args); err != nil {
+		log.Fatalf("Failed to estimate the gas of %d iterations of the computation loop: %v", iterations, err)
+	}
+	if err := rpcCli.CallContext(context.Background(), &computationCost, "kaia_estimateComputationCost", args, "latest"); err != nil {
+		log.Fatalf("Failed to estimate the computation cost of %d iterations of the computation loop: %v", iterations, err)
+	}
+	return uint64(gas), uint64(computationCost)
+}
+
+// calibrateComputationLimit returns the iterations of the loop contract per tx which cost the target of the configured metric.
+// Both costs grow linearly with the iterations, so they are estimated at two sizes and extrapolated, also beyond the limits.
+func calibrateComputationLimit(config *TCConfig, cl ComputationLimitConfig, contract common.Address) int64 {
+	rpcCli := config.RpcCliPool.Alloc().(*rpc.Client)
+	defer config.RpcCliPool.Free(rpcCli)
+
+	const n1, n2 = 1000, 2000
+	from := config.AccGrp.GetAccountRandomly().GetAddress()
+	gas1, cost1 := estimateLoopCost(rpcCli, from, contract, n1)
+	gas2, cost2 := estimateLoopCost(rpcCli, from, contract, n2)
+	gasPer, costPer := float64(gas2-gas1)/(n2-n1), float64(cost2-cost1)/(n2-n1)
+
+	base, per := float64(cost1)-costPer*n1, costPer
+	if cl.Metric == LimitMetricGas {
+		base, per = float64(gas1)-gasPer*n1, gasPer
+	}
+	target := computationLimitTarget(cl)
+	iterations := int64((float64(target) - base) / per)
+	if iterations < 1 {
+		iterations = 1
+	}
+
+	gas := float64(gas1) + gasPer*float64(iterations-n1)
+	cost := float64(cost1) + costPer*float64(iterations-n1)
+	log.Printf("computationLimitTC: %d iterations per tx for the %s of %d, about %.0f gas and %.0f computation cost per tx",
+		iterations, cl.Metric, target, gas, cost)
+	if gas > float64(cl.GasLimit) {
+		log.Printf("computationLimitTC: the txs run out of gas, as they need more than the gas limit %d", cl.GasLimit)
+	}
+	return iterations
+}
+
+// watchComputationLimitBlocks follows the new blocks, and periodically logs how many txs to the loop contract fit in a block
+// and how many of them hit the computation cost limit. The txs of all the slaves are counted, as they share the contract.
+func watchComputationLimitBlocks(config *TCConfig, contract common.Address) {
+	var (
+		next                      *big.Int
+		blocks, txs, maxTxs, hits uint64
+		lastReport                = time.Now()
+	)
+	for range time.Tick(1 * time.Second) {
+		cli := config.CliPool.Alloc().(*client.Client)
+		head, err := cli.BlockNumber(context.Background())
+		if err == nil && next == nil {
+			next = head
+		}
+		for ; err == nil && next.Cmp(head) <= 0; next.Add(next, big.NewInt(1)) {
+			var block *types.Block
+			if block, err = cli.BlockByNumber(context.Background(), next); err != nil {
+				break
+			}
+			var inBlock uint64
+			for _, tx := range block.Transactions() {
+				if tx.To() == nil || *tx.To() != contract {
+					continue
+				}
+				inBlock++
+				if receipt, err := cli.TransactionReceipt(context.Background(), tx.Hash()); err == nil && receipt.Status == types.ReceiptStatusErrOpcodeComputationCostLimitReached {
+					hits++
+				}
+			}
+			if inBlock > 0 {
+				blocks++
+				txs += inBlock
+				if inBlock > maxTxs {
+					maxTxs = inBlock
+				}
+			}
+		}
+		config.CliPool.Free(cli)
+
+		if time.Since(lastReport) >= 10*time.Second && blocks > 0 {
+			log.Printf("computationLimitTC: %d txs in %d blocks, %.1f txs per block on average and %d at most, %d (%.1f%%) hit the computation cost limit",
+				txs, blocks, float64(txs)/float64(blocks), maxTxs, hits, float64(hits)*100/float64(txs))
+			lastReport = time.Now()
+		}
+	}
+}
+
+// RunComputationLimitTC creates a closure for computation limit test case.
+// Every tx loops in the loop contract as many times as calibrated to cost the target of the metric.
+func RunComputationLimitTC(config *TCConfig) func() {
+	contract := config.SmartContractAccounts[account.ContractComputationLoop].GetAddress()
+	iterations := big.NewInt(calibrateComputationLimit(config, newComputationLimitConfig(config), contract))
+	go watchComputationLimitBlocks(config, contract)
+
+	txFunc := func(cli *client.Client, from *account.Account, to *account.Account) (interface{}, *big.Int, error) {
+		data := account.TestContractInfos[account.ContractComputationLoop].GenData(to.GetAddress(), iterations)
+		return from.TransferNewSmartContractExecutionTx(cli, to, nil, data)
+	}
+	return RunBaseWithContract(config, txFunc)
+}
@@ -0,0 +1,310 @@
+package testcase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/kaiachain/kaia-load-tester/klayslave/account"
+	"github.com/kaiachain/kaia/client"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/networks/rpc"
+	"github.com/myzhan/boomer"
+)
+
+// Tracers of the debug_trace* TCs
+const (
+	TracerCall         = "callTracer"
+	TracerPrestate     = "prestateTracer"
+	TracerStructLogger = "structLogger" // the default opcode logger, used when no tracer is given
+	TracerJS           = "js"           // a custom JS tracer
+)
+
+// Trace targets of the debug_trace* TCs
+const (
+	TraceTargetRecent     = "recent"     // txs recently sent by this slave and the recent blocks
+	TraceTargetHistorical = "historical" // random txs and blocks of the whole chain
+)
+
+// DebugTraceConfig configures the debug_trace* TCs.
+type DebugTraceConfig struct {
+	Tracers        []string // one of them is picked randomly for every call
+	JSTracer       string   // code of the custom JS tracer used for TracerJS
+	StructLogLimit int      // max number of struct logs per tx, 0 is unlimited
+	Timeout        time.Duration
+	Target         string
+}
+
+// Parameters of the debug_trace* TCs
+var (
+	debugTraceParams = []TCParam{
+		{Name: "tracers", Default: TracerCall, Usage: "tracers, one of them is picked for every call: callTracer, prestateTracer, structLogger or js, separated by comma"},
+		{Name: "jsFile", Default: "", Usage: "file of the custom JS tracer for the js tracer"},
+		{Name: "structLogLimit", Default: 1000, Usage: "max number of struct logs per tx of the structLogger tracer, 0 is unlimited"},
+		{Name: "timeout", Default: 30 * time.Second, Usage: "timeout of a trace given to the node, the call is abandoned a second later"},
+	}
+	debugTraceTargetParams = append(append([]TCParam{}, debugTraceParams...),
+		TCParam{Name: "target", Default: TraceTargetRecent, Usage: "target: recent (own txs and recent blocks) or historical (random txs and blocks)"},
+	)
+)
+
+// newDebugTraceConfig reads the configuration of a debug_trace* TC from its parameters, and the custom JS tracer.
+func newDebugTraceConfig(config *TCConfig) DebugTraceConfig {
+	dt := DebugTraceConfig{
+		StructLogLimit: config.IntParam("structLogLimit"),
+		Timeout:        config.DurationParam("timeout"),
+		Target:         TraceTargetRecent,
+	}
+	for _, tracer := range config.ListParam("tracers") {
+		switch tracer {
+		case TracerCall, TracerPrestate, TracerStructLogger:
+		case TracerJS:
+			code, err := os.ReadFile(config.StringParam("jsFile"))
+			if err != nil {
+				log.Fatalf("Failed to read %v.jsFile for the %v tracer: %v", config.Name, TracerJS, err)
+			}
+			dt.JSTracer = string(code)
+		default:
+			log.Fatalf("%v.tracers should be some of %v, %v, %v and %v: %v", config.Name, TracerCall, TracerPrestate, TracerStructLogger, TracerJS, tracer)
+		}
+		dt.Tracers = append(dt.Tracers, tracer)
+	}
+	if config.HasParam("target") {
+		dt.Target = config.StringParam("target")
+	}
+	if len(dt.Tracers) == 0 {
+		log.Fatalf("%v.tracers should not be empty", config.Name)
+	} else if dt.StructLogLimit < 0 || dt.Timeout <= 0 {
+		log.Fatalf("%v.structLogLimit should not be negative, and %v.timeout should be positive", config.Name, config.Name)
+	} else if dt.Target != TraceTargetRecent && dt.Target != TraceTargetHistorical {
+		log.Fatalf("%v.target should be %v or %v: %v", config.Name, TraceTargetRecent, TraceTargetHistorical, dt.Target)
+	}
+	return dt
+}
+
+// Recent tx related variables
+var (
+	recentTxsOnce sync.Once
+	recentTxs     *hashPool // hashes of the txs recently sent by the write TCs and mined, nil until a trace TC is initialized
+	recentTxsMu   sync.Mutex
+	recentTxsSent map[common.Hash]time.Time // sent txs which are not found in a mined block yet
+
+	recentTxPoolSize     = 1000
+	recentTxPollInterval = 500 * time.Millisecond
+	recentBlockRange     = 100 // blocks from the head which are traced as the recent blocks
+	historicalTries      = 10  // random blocks tried to find a tx in
+)
+
+// startRecentTxs starts following the mined blocks, so that only the sent txs which are mined are traced.
+// A pending or dropped tx has nothing to trace, and would fail the trace TC. It is started once by the first debugTraceTransactionTC.
+func startRecentTxs(endpoint string) {
+	recentTxsOnce.Do(func() {
+		cli, err := rpc.Dial(endpoint)
+		if err != nil {
+			log.Fatalf("Failed to connect the recent tx watcher to %v: %v", endpoint, err)
+		}
+		var head hexutil.Uint64
+		if err := cli.CallContext(context.Background(), &head, "kaia_blockNumber"); err != nil {
+			log.Fatalf("Failed to get the block number for the recent tx watcher: %v", err)
+		}
+
+		recentTxsMu.Lock()
+		recentTxsSent = make(map[common.Hash]time.Time)
+		recentTxsMu.Unlock()
+		recentTxs = newHashPool(recentTxPoolSize)
+
+		go recentTxLoop(cli, uint64(head)+1)
+	})
+}
+
+// recordRecentTx keeps the hash of a sent tx until it is found in a mined block, if a trace TC is initialized.
+func recordRecentTx(sent interface{}) {
+	recentTxsMu.Lock()
+	defer recentTxsMu.Unlock()
+	if recentTxsSent == nil {
+		return
+	}
+	if hash, _, _ := describeSentTx(sent); hash != (common.Hash{}) {
+		recentTxsSent[hash] = time.Now()
+	}
+}
+
+// recentTxLoop moves the sent txs found in every new block into the targets of the trace TCs.
+// A block which fails to be read is retried at the next poll.
+func recentTxLoop(cli *rpc.Client, next uint64) {
+	for range time.Tick(recentTxPollInterval) {
+		var head hexutil.Uint64
+		if err := cli.CallContext(context.Background(), &head, "kaia_blockNumber"); err != nil {
+			continue
+		}
+		for ; next <= uint64(head); next++ {
+			var block struct {
+				Transactions []common.Hash `json:"transactions"`
+			}
+			if err := cli.CallContext(context.Background(), &block, "kaia_getBlockByNumber", hexutil.Uint64(next), false); err != nil {
+				break
+			}
+			addRecentBlock(block.Transactions)
+		}
+	}
+}
+
+// addRecentBlock adds the sent txs of a block into the targets, and forgets the sent txs which are not mined in verifyTimeout.
+func addRecentBlock(txs []common.Hash) {
+	recentTxsMu.Lock()
+	defer recentTxsMu.Unlock()
+	for _, hash := range txs {
+		if _, ok := recentTxsSent[hash]; ok {
+			delete(recentTxsSent, hash)
+			recentTxs.add(hash)
+		}
+	}
+	for hash, sentAt := range recentTxsSent {
+		if time.Since(sentAt) > verifyTimeout {
+			delete(recentTxsSent, hash)
+		}
+	}
+}
+
+// traceConfigOf returns the trace config of the tracer in the form of the kaia tracers.TraceConfig.
+func traceConfigOf(dt DebugTraceConfig, tracer string) map[string]interface{} {
+	traceConfig := map[string]interface{}{"timeout": dt.Timeout.String()}
+	switch tracer {
+	case TracerStructLogger:
+		traceConfig["limit"] = dt.StructLogLimit
+	case TracerJS:
+		traceConfig["tracer"] = dt.JSTracer
+	default:
+		traceConfig["tracer"] = tracer
+	}
+	return traceConfig
+}
+
+// callTrace calls a debug_trace* API with a random tracer and publishes the result with the response size.
+// The call is abandoned on the client side if the node does not respond in a second after the trace timeout.
+func callTrace(config *TCConfig, dt DebugTraceConfig, rpcCli *rpc.Client, method string, args ...interface{}) {
+	tracer := dt.Tracers[rand.Intn(len(dt.Tracers))]
+	name := fmt.Sprintf("%s (%s) to %s", config.Name, tracer, config.EndPoint)
+
+	ctx, cancel := context.WithTimeout(context.Background(), dt.Timeout+time.Second)
+	defer cancel()
+
+	start := boomer.Now()
+	var result json.RawMessage
+	err := rpcCli.CallContext(ctx, &result, method, append(args, traceConfigOf(dt, tracer))...)
+	elapsed := boomer.Now() - start
+
+	if err == nil && len(result) == 0 {
+		err = errors.New("empty trace result")
+	}
+	if err == nil {
+		boomer.Events.Publish("request_success", "http", name, elapsed, int64(len(result)))
+	} else {
+		boomer.Events.Publish("request_failure", "http", name, elapsed, err.Error())
+	}
+}
+
+// randomHistoricalTx returns a tx hash of a random block. It returns an empty hash if none of the tried blocks has a tx.
+func randomHistoricalTx(ctx context.Context, cli *client.Client, rpcCli *rpc.Client) common.Hash {
+	for i := 0; i < historicalTries; i++ {
+		var block struct {
+			Transactions []common.Hash `json:"transactions"`
+		}
+		bn := getRandomBlockNumber(cli, ctx)
+		if err := rpcCli.CallContext(ctx, &block, "kaia_getBlockByNumber", hexutil.EncodeBig(bn), false); err != nil {
+			continue
+		}
+		if len(block.Transactions) > 0 {
+			return block.Transactions[rand.Intn(len(block.Transactions))]
+		}
+	}
+	return common.Hash{}
+}
+
+// RunDebugTraceTransactionTC creates a closure for debug_traceTransaction test case.
+// It traces a tx recently sent by the write TCs and mined, or a tx of a random block if there is none or the target is historical.
+func RunDebugTraceTransactionTC(config *TCConfig) func() {
+	dt := newDebugTraceConfig(config)
+	startRecentTxs(config.EndPoint)
+
+	return func() {
+		ctx := context.Background()
+		cli := config.CliPool.Alloc().(*client.Client)
+		defer config.CliPool.Free(cli)
+		rpcCli := config.RpcCliPool.Alloc().(*rpc.Client)
+		defer config.RpcCliPool.Free(rpcCli)
+
+		var hash common.Hash
+		if dt.Target == TraceTargetRecent && recentTxs.len() > 0 {
+			hash = recentTxs.random()
+		} else {
+			hash = randomHistoricalTx(ctx, cli, rpcCli)
+		}
+		if hash == (common.Hash{}) {
+			boomer.Events.Publish("request_failure", "http", config.Name+" to "+config.EndPoint, int64(0), "No tx to trace")
+			return
+		}
+
+		callTrace(config, dt, rpcCli, "debug_traceTransaction", hash)
+	}
+}
+
+// RunDebugTraceBlockByNumberTC creates a closure for debug_traceBlockByNumber test case.
+// It traces one of the recent blocks, or a random block if the target is historical.
+func RunDebugTraceBlockByNumberTC(config *TCConfig) func() {
+	dt := newDebugTraceConfig(config)
+	return func() {
+		ctx := context.Background()
+		cli := config.CliPool.Alloc().(*client.Client)
+		defer config.CliPool.Free(cli)
+		rpcCli := config.RpcCliPool.Alloc().(*rpc.Client)
+		defer config.RpcCliPool.Free(rpcCli)
+
+		var bn *big.Int
+		if dt.Target == TraceTargetRecent {
+			head, err := cli.BlockNumber(ctx)
+			if err != nil {
+				boomer.Events.Publish("request_failure", "http", config.Name+" to "+config.EndPoint, int64(0), err.Error())
+				return
+			}
+			bn = head.Sub(head, big.NewInt(int64(rand.Intn(recentBlockRange))))
+			if bn.Sign() < 0 {
+				bn.SetInt64(0)
+			}
+		} else {
+			bn = getRandomBlockNumber(cli, ctx)
+		}
+
+		callTrace(config, dt, rpcCli, "debug_traceBlockByNumber", hexutil.EncodeBig(bn))
+	}
+}
+
+// RunDebugTraceCallTC creates a closure for debug_traceCall test case.
+// It traces a state-changing call of the read API test contract on the latest block.
+func RunDebugTraceCallTC(config *TCConfig) func() {
+	dt := newDebugTraceConfig(config)
+	return func() {
+		rpcCli := config.RpcCliPool.Alloc().(*rpc.Client)
+		defer config.RpcCliPool.Free(rpcCli)
+
+		from := config.AccGrp.GetAccountRandomly().GetAddress()
+		contractAddr := config.SmartContractAccounts[account.ContractReadApiCallContract].GetAddress()
+		data := account.TestContractInfos[account.ContractReadApiCallContract].GenData(from, big.NewInt(1))
+		callArgs := map[string]interface{}{
+			"from":  from,
+			"to":    contractAddr,
+			"gas":   hexutil.Uint64(1100000),
+			"input": hexutil.Bytes(data),
+		}
+
+		callTrace(config, dt, rpcCli, "debug_traceCall", callArgs, "latest")
+	}
+}
@@ -0,0 +1,288 @@
+package testcase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/big"
+	"math/rand"
+	"sync"
+
+	"github.com/kaiachain/kaia-load-tester/klayslave/account"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/client"
+	"github.com/kaiachain/kaia/common"
+	"github.com/myzhan/boomer"
+)
+
+// eip7702PoolSizeParam is the parameter of every EIP-7702 TC. The pool is shared, so the first TC initialized decides its size.
+var eip7702PoolSizeParam = TCParam{Name: "poolSize", Default: 100, Usage: "number of the EOAs delegated by the EIP-7702 TCs, shared by them, so the first TC initialized decides it"}
+
+// delegatedEOA is an EOA of the pool which the EIP-7702 TCs delegate.
+// It never sends a tx and has no balance, so that its nonce is only increased by its own authorizations.
+// Only one authorization of an EOA is in flight at a time, so that the authorizations are mined in the order of their nonces.
+type delegatedEOA struct {
+	acc      *account.Account
+	mu       sync.Mutex
+	nonce    uint64         // nonce of the next authorization
+	delegate common.Address // delegate of the last sent authorization, zero if revoked or never delegated
+	inFlight bool           // an authorization is reserved or sent, and its set code tx has no receipt yet
+}
+
+var (
+	eip7702Once sync.Once
+	eip7702Pool []*delegatedEOA
+
+	errNoIdleEOA = errors.New("no EOA of the pool is idle, every EOA has an authorization in flight; increase the poolSize parameter")
+)
+
+// initEIP7702Pool creates the pool of the delegated EOAs once for all the EIP-7702 TCs.
+func initEIP7702Pool(config *TCConfig) {
+	eip7702Once.Do(func() {
+		poolSize := config.IntParam(eip7702PoolSizeParam.Name)
+		if poolSize <= 0 {
+			log.Fatalf("%v.%v should be positive: %v", config.Name, eip7702PoolSizeParam.Name, poolSize)
+		}
+		eip7702Pool = make([]*delegatedEOA, poolSize)
+		for i := range eip7702Pool {
+			eip7702Pool[i] = &delegatedEOA{acc: account.NewAccount(i)}
+		}
+	})
+	startMinedWatcher(config.EndPoint)
+}
+
+// reserve marks the EOA in flight if it is idle, and returns whether it did.
+func (eoa *delegatedEOA) reserve() bool {
+	eoa.mu.Lock()
+	defer eoa.mu.Unlock()
+	if eoa.inFlight {
+		return false
+	}
+	eoa.inFlight = true
+	return true
+}
+
+// release marks the EOA idle, so that the next authorization can be sent.
+func (eoa *delegatedEOA) release() {
+	eoa.mu.Lock()
+	eoa.inFlight = false
+	eoa.mu.Unlock()
+}
+
+// pickDelegatedEOAs reserves n distinct random idle EOAs of the pool. It returns errNoIdleEOA if there are not enough of them.
+func pickDelegatedEOAs(n int) ([]*delegatedEOA, error) {
+	eoas := make([]*delegatedEOA, 0, n)
+	for _, idx := range rand.Perm(len(eip7702Pool)) {
+		if eip7702Pool[idx].reserve() {
+			eoas = append(eoas, eip7702Pool[idx])
+			if len(eoas) == n {
+				return eoas, nil
+			}
+		}
+	}
+	for _, eoa := range eoas {
+		eoa.release()
+	}
+	return nil, errNoIdleEOA
+}
+
+// pickEOADelegatedTo returns a random EOA whose last authorization delegated it to the delegate, or any EOA if none is found in a few tries.
+// If reserve is true, only an idle EOA is returned and it is reserved for the next authorization.
+func pickEOADelegatedTo(delegate common.Address, reserve bool) (*delegatedEOA, error) {
+	var fallback *delegatedEOA
+	for i := 0; i < 10; i++ {
+		eoa := eip7702Pool[rand.Intn(len(eip7702Pool))]
+		eoa.mu.Lock()
+		matched, busy := eoa.delegate == delegate, reserve && eoa.inFlight
+		eoa.mu.Unlock()
+		if busy {
+			continue
+		}
+		if matched {
+			fallback = eoa
+			break
+		}
+		if fallback == nil {
+			fallback = eoa
+		}
+	}
+	if !reserve {
+		if fallback == nil {
+			fallback = eip7702Pool[rand.Intn(len(eip7702Pool))]
+		}
+		return fallback, nil
+	}
+	if fallback != nil && fallback.reserve() {
+		return fallback, nil
+	}
+	eoas, err := pickDelegatedEOAs(1)
+	if err != nil {
+		return nil, err
+	}
+	return eoas[0], nil
+}
+
+// sendSetCode sends a set code tx from the sender with the authorizations of the reserved EOAs to the delegates, which calls the first EOA with the data.
+// Once the tx is sent, the EOAs stay in flight until checkEIP7702Delegations finds its receipt, otherwise they are released at once.
+func sendSetCode(cli *client.Client, config *TCConfig, from *account.Account, eoas []*delegatedEOA, delegates []common.Address, data []byte) (interface{}, *big.Int, error) {
+	authList := make([]types.SetCodeAuthorization, len(eoas))
+	for i, eoa := range eoas {
+		eoa.mu.Lock()
+		auth, err := eoa.acc.SignSetCodeAuthorization(delegates[i], eoa.nonce)
+		eoa.mu.Unlock()
+		if err != nil {
+			releaseEOAs(eoas)
+			return nil, nil, err
+		}
+		authList[i] = auth
+	}
+
+	hash, gasPrice, err := from.TransferNewEthereumSetCodeTx(cli, eoas[0].acc, big.NewInt(0), data, authList)
+	if err != nil {
+		releaseEOAs(eoas)
+		return hash, gasPrice, err
+	}
+	for i, eoa := range eoas {
+		eoa.mu.Lock()
+		eoa.nonce++
+		eoa.delegate = delegates[i]
+		eoa.mu.Unlock()
+	}
+	queued := watchMined(func(cli *client.Client) {
+		checkEIP7702Delegations(cli, config, hash, eoas)
+	})
+	if !queued {
+		boomer.Events.Publish("request_failure", "http", "eip7702 delegation check to "+config.EndPoint, 0, "the mined tx watcher queue is full")
+		releaseEOAs(eoas)
+	}
+	return hash, gasPrice, nil
+}
+
+func releaseEOAs(eoas []*delegatedEOA) {
+	for _, eoa := range eoas {
+		eoa.release()
+	}
+}
+
+// checkEIP7702Delegations waits for the receipt of the set code tx, and then checks the code and the nonce of its EOAs against their authorizations.
+// The result is reported as "eip7702 delegation check". A mismatch, e.g. an authorization which was skipped because the tx was not mined,
+// is adopted from the chain so that the next authorizations are valid. The EOAs are released afterwards.
+func checkEIP7702Delegations(cli *client.Client, config *TCConfig, hash common.Hash, eoas []*delegatedEOA) {
+	defer releaseEOAs(eoas)
+	name := "eip7702 delegation check to " + config.EndPoint
+
+	start := boomer.Now()
+	if _, err := waitMined(cli, hash); err != nil {
+		boomer.Events.Publish("request_failure", "http", name, boomer.Now()-start, err.Error())
+	}
+	for _, eoa := range eoas {
+		start := boomer.Now()
+		code, err := cli.CodeAt(context.Background(), eoa.acc.GetAddress(), nil)
+		var nonce uint64
+		if err == nil {
+			nonce, err = cli.NonceAt(context.Background(), eoa.acc.GetAddress(), nil)
+		}
+		elapsed := boomer.Now() - start
+		if err != nil {
+			boomer.Events.Publish("request_failure", "http", name, elapsed, err.Error())
+			continue
+		}
+
+		delegate, _ := types.ParseDelegation(code)
+		eoa.mu.Lock()
+		if delegate == eoa.delegate && nonce == eoa.nonce {
+			boomer.Events.Publish("request_success", "http", name, elapsed, int64(len(code)))
+		} else {
+			boomer.Events.Publish("request_failure", "http", name, elapsed, "the code or the nonce of the EOA differs from its last authorization")
+			eoa.delegate, eoa.nonce = delegate, nonce
+		}
+		eoa.mu.Unlock()
+	}
+}
+
+// RunEIP7702SetCodeTC creates a closure for EIP-7702 set code test case.
+// It delegates an EOA of the pool to the delegate contract and calls it in the same tx.
+func RunEIP7702SetCodeTC(config *TCConfig) func() {
+	initEIP7702Pool(config)
+	txFunc := func(cli *client.Client, from *account.Account, to *account.Account) (interface{}, *big.Int, error) {
+		eoas, err := pickDelegatedEOAs(1)
+		if err != nil {
+			return nil, nil, err
+		}
+		return sendSetCode(cli, config, from, eoas, []common.Address{to.GetAddress()}, nil)
+	}
+	return RunBaseWithContract(config, txFunc)
+}
+
+// RunEIP7702MultiAuthTC creates a closure for EIP-7702 multiple authorizations test case.
+// It delegates the auths parameter EOAs in a tx, and the first one calls the others in a batch.
+func RunEIP7702MultiAuthTC(config *TCConfig) func() {
+	initEIP7702Pool(config)
+	auths := config.IntParam("auths")
+	if auths <= 0 || auths > 50 || auths > len(eip7702Pool) {
+		log.Fatalf("%v.auths should be between 1 and 50, and not more than the pool size %v: %v", config.Name, len(eip7702Pool), auths)
+	}
+	txFunc := func(cli *client.Client, from *account.Account, to *account.Account) (interface{}, *big.Int, error) {
+		eoas, err := pickDelegatedEOAs(auths)
+		if err != nil {
+			return nil, nil, err
+		}
+		delegates := make([]common.Address, len(eoas))
+		targets := make([]common.Address, 0, len(eoas)-1)
+		for i, eoa := range eoas {
+			delegates[i] = to.GetAddress()
+			if i > 0 {
+				targets = append(targets, eoa.acc.GetAddress())
+			}
+		}
+		return sendSetCode(cli, config, from, eoas, delegates, account.GenEIP7702BatchData(targets))
+	}
+	return RunBaseWithContract(config, txFunc)
+}
+
+// RunEIP7702RedelegateTC creates a closure for EIP-7702 re-delegation test case.
+// It moves the delegation of an EOA between the delegate contract and the general purpose contract.
+func RunEIP7702RedelegateTC(config *TCConfig) func() {
+	initEIP7702Pool(config)
+	general := config.SmartContractAccounts[account.ContractGeneral].GetAddress()
+	txFunc := func(cli *client.Client, from *account.Account, to *account.Account) (interface{}, *big.Int, error) {
+		eoa, err := pickEOADelegatedTo(to.GetAddress(), true)
+		if err != nil {
+			return nil, nil, err
+		}
+		eoa.mu.Lock()
+		delegate := general
+		if eoa.delegate == general {
+			delegate = to.GetAddress()
+		}
+		eoa.mu.Unlock()
+		return sendSetCode(cli, config, from, []*delegatedEOA{eoa}, []common.Address{delegate}, nil)
+	}
+	return RunBaseWithContract(config, txFunc)
+}
+
+// RunEIP7702RevokeTC creates a closure for EIP-7702 revocation test case.
+// It revokes the delegation of an EOA by the authorization to the zero address.
+func RunEIP7702RevokeTC(config *TCConfig) func() {
+	initEIP7702Pool(config)
+	txFunc := func(cli *client.Client, from *account.Account, to *account.Account) (interface{}, *big.Int, error) {
+		eoa, err := pickEOADelegatedTo(to.GetAddress(), true)
+		if err != nil {
+			return nil, nil, err
+		}
+		return sendSetCode(cli, config, from, []*delegatedEOA{eoa}, []common.Address{{}}, nil)
+	}
+	return RunBaseWithContract(config, txFunc)
+}
+
+// RunEIP7702DelegatedCallTC creates a closure for EIP-7702 delegated call test case.
+// It calls an EOA delegated to the delegate contract by an ethereum legacy tx, which calls another EOA of the pool in a batch.
+func RunEIP7702DelegatedCallTC(config *TCConfig) func() {
+	initEIP7702Pool(config)
+	txFunc := func(cli *client.Client, from *account.Account, to *account.Account) (interface{}, *big.Int, error) {
+		eoa, _ := pickEOADelegatedTo(to.GetAddress(), false)
+		target := eip7702Pool[rand.Intn(len(eip7702Pool))].acc.GetAddress()
+		return from.TransferNewLegacyTxWithEth(cli, eoa.acc, big.NewInt(0), account.GenEIP7702BatchData([]common.Address{target}))
+	}
+	return RunBaseWithContract(config, txFunc)
+}
@@ -1,6 +1,7 @@
 package testcase
 
 import (
+	"log"
 	"math/big"
 	"math/rand"
 
@@ -22,10 +23,11 @@ func RunBaseWithContract(config *TCConfig, txFunc SmartContractTxFunc) func() {
 		to := config.SmartContractAccounts[config.TestContracts[0]]
 
 		start := boomer.Now()
-		_, _, err := txFunc(cli, from, to)
+		sent, _, err := txFunc(cli, from, to)
 		elapsed := boomer.Now() - start
 
 		if err == nil {
+			onTxSent(config.Name, sent)
 			boomer.Events.Publish("request_success", "http", config.Name+" to "+config.EndPoint, elapsed, int64(10))
 		} else {
 			boomer.Events.Publish("request_failure", "http", config.Name+" to "+config.EndPoint, elapsed, err.Error())
@@ -49,15 +51,17 @@ func RunNewFeeDelegatedSmartContractExecutionTC(config *TCConfig) func() {
 }
 
 func RunNewFeeDelegatedSmartContractExecutionWithRatioTC(config *TCConfig) func() {
+	feeRatio := config.feeRatio()
 	txFunc := func(cli *client.Client, from *account.Account, to *account.Account) (interface{}, *big.Int, error) {
-		return from.TransferNewFeeDelegatedSmartContractExecutionWithRatioTx(cli, to, big.NewInt(0))
+		return from.TransferNewFeeDelegatedSmartContractExecutionWithRatioTx(cli, to, big.NewInt(0), feeRatio)
 	}
 	return RunBaseWithContract(config, txFunc)
 }
 
 func RunCpuHeavyTC(config *TCConfig) func() {
+	size := config.IntParam("size")
 	txFunc := func(cli *client.Client, from *account.Account, to *account.Account) (interface{}, *big.Int, error) {
-		cpuHeavyValue := big.NewInt(100)
+		cpuHeavyValue := big.NewInt(int64(size))
 		cpuHeavyData := account.TestContractInfos[account.ContractCPUHeavy].GenData(from.GetAddress(), cpuHeavyValue)
 		return from.TransferNewSmartContractExecutionTx(cli, to, big.NewInt(0), cpuHeavyData)
 	}
@@ -65,9 +69,13 @@ func RunCpuHeavyTC(config *TCConfig) func() {
 }
 
 func RunLargeMemoTC(config *TCConfig) func() {
+	minSize, maxSize := config.IntParam("minSize"), config.IntParam("maxSize")
+	if minSize < 0 || minSize > maxSize {
+		log.Fatalf("%v.minSize(%v) should be between 0 and maxSize(%v)", config.Name, minSize, maxSize)
+	}
 	txFunc := func(cli *client.Client, from *account.Account, to *account.Account) (interface{}, *big.Int, error) {
-		// Generate random memo size between 50 and 2000
-		memoSize := big.NewInt(int64(50 + rand.Intn(1951)))
+		// Generate random memo size between minSize and maxSize
+		memoSize := big.NewInt(int64(minSize + rand.Intn(maxSize-minSize+1)))
 		memoData := account.TestContractInfos[account.ContractLargeMemo].GenData(from.GetAddress(), memoSize)
 		return from.TransferNewSmartContractExecutionTx(cli, to, big.NewInt(0), memoData)
 	}
@@ -75,8 +83,9 @@ func RunLargeMemoTC(config *TCConfig) func() {
 }
 
 func RunErc20TransferTC(config *TCConfig) func() {
+	maxValue := config.maxValue()
 	txFunc := func(cli *client.Client, from *account.Account, to *account.Account) (interface{}, *big.Int, error) {
-		erc20Value := big.NewInt(int64(rand.Int() % 3))
+		erc20Value := big.NewInt(int64(rand.Intn(maxValue + 1)))
 		erc20Data := account.TestContractInfos[account.ContractErc20].GenData(to.GetAddress(), erc20Value)
 		return from.TransferNewSmartContractExecutionTx(cli, to, nil, erc20Data)
 	}
@@ -115,10 +124,11 @@ func RunErc721TransferTC(config *TCConfig) func() {
 		}
 
 		start := boomer.Now()
-		_, _, err := fromAcc.TransferERC721(false, cli, config.SmartContractAccounts[account.ContractErc721].GetAddress(), toAcc, tokenId)
+		tx, _, err := fromAcc.TransferERC721(false, cli, config.SmartContractAccounts[account.ContractErc721].GetAddress(), toAcc, tokenId)
 		elapsed := boomer.Now() - start
 
 		if err == nil {
+			onTxSent(config.Name, tx)
 			boomer.Events.Publish("request_success", "http", config.Name+" to "+config.EndPoint, elapsed, int64(10))
 			// Transfer successful, add token to destination account
 			account.ERC721Ledger.PutToken(toAcc.GetAddress(), tokenId)
@@ -135,8 +145,9 @@ func RunGaslessTransactionTC(config *TCConfig) func() {
 	txFunc := func(cli *client.Client, from *account.Account, to *account.Account) (interface{}, *big.Int, error) {
 		testTokenAccount := config.SmartContractAccounts[account.ContractGaslessToken]
 		gsrAccount := config.SmartContractAccounts[account.ContractGaslessSwapRouter]
-		_, _, _, err := from.TransferNewGaslessTx(cli, testTokenAccount, gsrAccount)
-		return nil, nil, err
+		// The swap tx is verified, since it is mined only after the approve tx
+		_, swapHash, gasPrice, err := from.TransferNewGaslessTx(cli, testTokenAccount, gsrAccount)
+		return swapHash, gasPrice, err
 	}
 	return RunBaseWithContract(config, txFunc)
 }
@@ -242,10 +253,11 @@ func RunUserStorageSetGetTC(config *TCConfig) func() {
 		// Then, call get function
 		getValue := big.NewInt(0)
 		getData := account.TestContractInfos[account.ContractUserStorage].GenData(from.GetAddress(), getValue)
-		_, _, getErr := from.TransferNewSmartContractExecutionTx(cli, userStorageContractAccount, nil, getData)
+		getTx, _, getErr := from.TransferNewSmartContractExecutionTx(cli, userStorageContractAccount, nil, getData)
 
 		elapsed := boomer.Now() - start
 		if getErr == nil {
+			onTxSent(config.Name, getTx)
 			boomer.Events.Publish("request_success", "http", "userStorageSetGet to "+config.EndPoint, elapsed, int64(10))
 		} else {
 			boomer.Events.Publish("request_failure", "http", "userStorageSetGet to "+config.EndPoint, elapsed, getErr.Error())
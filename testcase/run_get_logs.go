@@ -0,0 +1,353 @@
+package testcase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand"
+
+	"github.com/kaiachain/kaia-load-tester/klayslave/account"
+	"github.com/kaiachain/kaia/client"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/crypto"
+	"github.com/kaiachain/kaia/networks/rpc"
+	"github.com/myzhan/boomer"
+)
+
+// Filters of the log query TCs
+const (
+	LogFilterAddress = "address" // the contract address only
+	LogFilterTopic   = "topic"   // the contract address and the event signature
+	LogFilterIndexed = "indexed" // the contract address, the event signature and an indexed test account
+	LogFilterGlobal  = "global"  // the event signature only, which matches the logs of every contract
+	LogFilterDeploy  = "deploy"  // an event emitted once by the constructor, queried from the genesis block
+)
+
+// LogQueryConfig configures the log query TCs.
+type LogQueryConfig struct {
+	RangeWidths    []int    // block range widths of getLogs, one of them is picked randomly for every call
+	Filters        []string // filters of getLogs and newFilter, one of them is picked randomly
+	Namespaces     []string // RPC namespaces, e.g. kaia, klay and eth, one of them is picked randomly
+	FilterPoolSize int      // max number of the installed filters polled by filterChangesTC
+}
+
+// logQueryParams are the parameters of getLogsTC and filterChangesTC.
+var logQueryParams = []TCParam{
+	{Name: "filters", Default: "address,topic,indexed", Usage: "filters, one of them is picked for every query: address, topic, indexed, global or deploy, separated by comma"},
+	{Name: "namespaces", Default: "kaia,eth", Usage: "RPC namespaces, one of them is picked for every query: kaia, klay or eth, separated by comma"},
+}
+
+// newLogQueryConfig reads the configuration of a log query TC from its parameters.
+func newLogQueryConfig(config *TCConfig) LogQueryConfig {
+	lq := LogQueryConfig{Filters: config.ListParam("filters"), Namespaces: config.ListParam("namespaces")}
+	for _, filter := range lq.Filters {
+		switch filter {
+		case LogFilterAddress, LogFilterTopic, LogFilterIndexed, LogFilterGlobal, LogFilterDeploy:
+		default:
+			log.Fatalf("%v.filters should be some of %v, %v, %v, %v and %v: %v", config.Name, LogFilterAddress, LogFilterTopic, LogFilterIndexed, LogFilterGlobal, LogFilterDeploy, filter)
+		}
+	}
+	for _, ns := range lq.Namespaces {
+		if !isNamespace(ns) {
+			log.Fatalf("%v.namespaces should be some of kaia, klay and eth: %v", config.Name, ns)
+		}
+	}
+	if len(lq.Filters) == 0 || len(lq.Namespaces) == 0 {
+		log.Fatalf("%v.filters and %v.namespaces should not be empty", config.Name, config.Name)
+	}
+	if config.HasParam("rangeWidths") {
+		for _, width := range config.IntListParam("rangeWidths") {
+			if width <= 0 {
+				log.Fatalf("%v.rangeWidths should be positive: %v", config.Name, width)
+			}
+			lq.RangeWidths = append(lq.RangeWidths, width)
+		}
+		if len(lq.RangeWidths) == 0 {
+			log.Fatalf("%v.rangeWidths should not be empty", config.Name)
+		}
+	}
+	if config.HasParam("filterPoolSize") {
+		if lq.FilterPoolSize = config.IntParam("filterPoolSize"); lq.FilterPoolSize <= 0 {
+			log.Fatalf("%v.filterPoolSize should be positive: %v", config.Name, lq.FilterPoolSize)
+		}
+	}
+	return lq
+}
+
+// Event signatures of the test contracts
+var (
+	transferEvent          = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	sendInviteeRewardEvent = crypto.Keccak256Hash([]byte("sendInviteeReward(address,uint256)"))
+	sendHostRewardEvent    = crypto.Keccak256Hash([]byte("sendHostReward(address,uint256)"))
+	updateOwnerEvent       = crypto.Keccak256Hash([]byte("UpdateOwner(address)"))
+)
+
+// logTarget is an event of a test contract which the log query TCs look for.
+type logTarget struct {
+	contract account.TestContract
+	event    common.Hash
+	topics   int         // number of the topics of the event, including the signature
+	indexed  bool        // whether the first indexed argument is a test account
+	pair     common.Hash // event emitted exactly once with every event, if any
+}
+
+var logTargets = []logTarget{
+	{contract: account.ContractErc20, event: transferEvent, topics: 3, indexed: true},
+	{contract: account.ContractErc721, event: transferEvent, topics: 4, indexed: true},
+	{contract: account.ContractInternalTxMain, event: sendInviteeRewardEvent, topics: 3, pair: sendHostRewardEvent},
+}
+
+// rpcLog is the subset of the log fields returned by getLogs and getFilterChanges which the TCs check.
+type rpcLog struct {
+	Address     common.Address `json:"address"`
+	Topics      []common.Hash  `json:"topics"`
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+}
+
+// logQuery is a filter and what the logs returned for it should satisfy.
+type logQuery struct {
+	filter   string
+	target   logTarget
+	address  *common.Address
+	topics   [][]common.Hash
+	from, to uint64 // block range of getLogs. to is 0 for the polled filters, whose counts are not checked.
+}
+
+// args returns the filter object of the getLogs and newFilter APIs.
+func (q *logQuery) args(withRange bool) map[string]interface{} {
+	args := map[string]interface{}{"topics": q.topics}
+	if q.address != nil {
+		args["address"] = q.address
+	}
+	if withRange {
+		args["fromBlock"] = hexutil.Uint64(q.from)
+		args["toBlock"] = hexutil.Uint64(q.to)
+	}
+	return args
+}
+
+// check returns an error if a log does not match the query, or the count of the known events is not as emitted.
+// Over the blocks covered by the log ledger, the count is also checked against the logs of the txs sent by the slave.
+func (q *logQuery) check(logs []rpcLog) error {
+	var events, pairs int
+	for _, l := range logs {
+		if q.address != nil && l.Address != *q.address {
+			return fmt.Errorf("log of an unexpected address %v", l.Address.String())
+		}
+		if q.to != 0 && (uint64(l.BlockNumber) < q.from || uint64(l.BlockNumber) > q.to) {
+			return fmt.Errorf("log of the block %d out of the range [%d, %d]", l.BlockNumber, q.from, q.to)
+		}
+		for i, topics := range q.topics {
+			if i >= len(l.Topics) || (len(topics) > 0 && topics[0] != l.Topics[i]) {
+				return fmt.Errorf("log whose topic %d does not match the filter", i)
+			}
+		}
+		if len(l.Topics) == 0 {
+			continue
+		}
+		switch l.Topics[0] {
+		case q.target.event:
+			events++
+			// the contracts of the other TCs may have the same event with a different number of the indexed arguments
+			if q.address != nil && len(l.Topics) != q.target.topics {
+				return fmt.Errorf("log with %d topics, expected %d", len(l.Topics), q.target.topics)
+			}
+		case q.target.pair:
+			pairs++
+		}
+	}
+
+	switch {
+	case q.filter == LogFilterDeploy && q.to != 0 && len(logs) != 1:
+		return fmt.Errorf("%d logs of the constructor event, expected 1", len(logs))
+	case q.filter == LogFilterAddress && q.to != 0 && q.target.pair != (common.Hash{}) && events != pairs:
+		return fmt.Errorf("%d logs of the event and %d logs of its pair, expected the same", events, pairs)
+	case q.filter == LogFilterDeploy || q.filter == LogFilterGlobal:
+		return nil
+	}
+
+	// The logs indexed by a test account are only emitted by the txs of the slave, while the other slaves may add logs to the shared contracts.
+	expected, covered := expectedLogs(q)
+	switch {
+	case !covered:
+	case q.filter == LogFilterIndexed && q.target.indexed && len(logs) != expected:
+		return fmt.Errorf("%d logs in the blocks [%d, %d], expected %d from the sent txs", len(logs), q.from, q.to, expected)
+	case len(logs) < expected:
+		return fmt.Errorf("%d logs in the blocks [%d, %d], expected at least %d from the sent txs", len(logs), q.from, q.to, expected)
+	}
+	return nil
+}
+
+// newLogQuery builds a random query of the configured filters on an event of the deployed test contracts.
+func newLogQuery(config *TCConfig, lq LogQueryConfig) *logQuery {
+	var targets []logTarget
+	for _, target := range logTargets {
+		if config.SmartContractAccounts[target.contract] != nil {
+			targets = append(targets, target)
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+	target := targets[rand.Intn(len(targets))]
+	q := &logQuery{filter: lq.Filters[rand.Intn(len(lq.Filters))], target: target}
+	address := config.SmartContractAccounts[target.contract].GetAddress()
+
+	switch q.filter {
+	case LogFilterAddress:
+		q.address = &address
+	case LogFilterIndexed:
+		q.address = &address
+		q.topics = [][]common.Hash{{target.event}}
+		if target.indexed {
+			from := config.AccGrp.GetAccountRandomly().GetAddress()
+			q.topics = append(q.topics, []common.Hash{common.BytesToHash(from.Bytes())})
+		}
+	case LogFilterGlobal:
+		q.topics = [][]common.Hash{{target.event}}
+	case LogFilterDeploy:
+		main := config.SmartContractAccounts[account.ContractInternalTxMain]
+		if main == nil {
+			return nil
+		}
+		mainAddress := main.GetAddress()
+		q.target = logTarget{contract: account.ContractInternalTxMain, event: updateOwnerEvent, topics: 2}
+		q.address = &mainAddress
+		q.topics = [][]common.Hash{{updateOwnerEvent}}
+	default:
+		q.address = &address
+		q.topics = [][]common.Hash{{target.event}}
+	}
+	return q
+}
+
+// publishLogQuery publishes the result of a log query with the response size, after checking the logs.
+func publishLogQuery(name string, elapsed int64, result json.RawMessage, err error, q *logQuery) {
+	if err == nil {
+		var logs []rpcLog
+		if err = json.Unmarshal(result, &logs); err == nil {
+			err = q.check(logs)
+		}
+	}
+	if err == nil {
+		boomer.Events.Publish("request_success", "http", name, elapsed, int64(len(result)))
+	} else {
+		boomer.Events.Publish("request_failure", "http", name, elapsed, err.Error())
+	}
+}
+
+// RunGetLogsTC creates a closure for getLogs test case.
+// It queries the events of the test contracts in a random width of the recent blocks before the head and checks the returned logs.
+func RunGetLogsTC(config *TCConfig) func() {
+	lq := newLogQueryConfig(config)
+	maxWidth := 0
+	for _, width := range lq.RangeWidths {
+		if width > maxWidth {
+			maxWidth = width
+		}
+	}
+	startLogLedger(config.EndPoint, uint64(maxWidth))
+	return func() {
+		ctx := context.Background()
+		cli := config.CliPool.Alloc().(*client.Client)
+		defer config.CliPool.Free(cli)
+		rpcCli := config.RpcCliPool.Alloc().(*rpc.Client)
+		defer config.RpcCliPool.Free(rpcCli)
+
+		ns := lq.Namespaces[rand.Intn(len(lq.Namespaces))]
+		q := newLogQuery(config, lq)
+		if q == nil {
+			boomer.Events.Publish("request_failure", "http", config.Name+" to "+config.EndPoint, int64(0), "No deployed contract for the filter")
+			return
+		}
+		name := fmt.Sprintf("%s (%s_getLogs, %s) to %s", config.Name, ns, q.filter, config.EndPoint)
+
+		head, err := cli.BlockNumber(ctx)
+		if err != nil {
+			boomer.Events.Publish("request_failure", "http", name, int64(0), err.Error())
+			return
+		}
+		// The range ends at the block before the head, which the log ledger covers once the head is mined.
+		q.to = head.Uint64()
+		if q.to > 1 {
+			q.to--
+		}
+		if q.filter != LogFilterDeploy {
+			width := uint64(lq.RangeWidths[rand.Intn(len(lq.RangeWidths))])
+			if q.to >= width {
+				q.from = q.to - width + 1
+			}
+		}
+
+		start := boomer.Now()
+		var result json.RawMessage
+		err = rpcCli.CallContext(ctx, &result, ns+"_getLogs", q.args(true))
+		elapsed := boomer.Now() - start
+
+		publishLogQuery(name, elapsed, result, err, q)
+	}
+}
+
+// installedFilter is a filter installed by filterChangesTC.
+type installedFilter struct {
+	id    string
+	ns    string
+	query *logQuery
+}
+
+// RunFilterChangesTC creates a closure for newFilter + getFilterChanges test case.
+// It keeps up to the filterPoolSize parameter filters installed and polls one of them for every call.
+// A filter which the node forgot, e.g. by the timeout, is installed again.
+func RunFilterChangesTC(config *TCConfig) func() {
+	lq := newLogQueryConfig(config)
+	filters := make(chan *installedFilter, lq.FilterPoolSize)
+
+	return func() {
+		ctx := context.Background()
+		rpcCli := config.RpcCliPool.Alloc().(*rpc.Client)
+		defer config.RpcCliPool.Free(rpcCli)
+
+		var f *installedFilter
+		select {
+		case f = <-filters:
+		default:
+			ns := lq.Namespaces[rand.Intn(len(lq.Namespaces))]
+			q := newLogQuery(config, lq)
+			if q == nil {
+				boomer.Events.Publish("request_failure", "http", config.Name+" to "+config.EndPoint, int64(0), "No deployed contract for the filter")
+				return
+			}
+			name := fmt.Sprintf("%s (%s_newFilter) to %s", config.Name, ns, config.EndPoint)
+
+			start := boomer.Now()
+			var id string
+			err := rpcCli.CallContext(ctx, &id, ns+"_newFilter", q.args(false))
+			elapsed := boomer.Now() - start
+			if err != nil {
+				boomer.Events.Publish("request_failure", "http", name, elapsed, err.Error())
+				return
+			}
+			boomer.Events.Publish("request_success", "http", name, elapsed, int64(len(id)))
+			f = &installedFilter{id: id, ns: ns, query: q}
+		}
+
+		name := fmt.Sprintf("%s (%s_getFilterChanges, %s) to %s", config.Name, f.ns, f.query.filter, config.EndPoint)
+		start := boomer.Now()
+		var result json.RawMessage
+		err := rpcCli.CallContext(ctx, &result, f.ns+"_getFilterChanges", f.id)
+		elapsed := boomer.Now() - start
+		publishLogQuery(name, elapsed, result, err, f.query)
+
+		if err != nil {
+			return // install a new one instead
+		}
+		select {
+		case filters <- f:
+		default:
+			var uninstalled bool
+			rpcCli.CallContext(ctx, &uninstalled, f.ns+"_uninstallFilter", f.id)
+		}
+	}
+}
@@ -0,0 +1,478 @@
+package testcase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"math/big"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	kaia "github.com/kaiachain/kaia"
+	"github.com/kaiachain/kaia-load-tester/klayslave/account"
+	"github.com/kaiachain/kaia/accounts/abi"
+	"github.com/kaiachain/kaia/client"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/params"
+	"github.com/myzhan/boomer"
+)
+
+// Block distributions of the historical read TCs
+const (
+	HistoricalDistUniform = "uniform" // any block from the genesis to the head
+	HistoricalDistRecent  = "recent"  // exponentially distributed depth from the head with the mean of Depth
+	HistoricalDistFixed   = "fixed"   // the block of Depth blocks before the head
+)
+
+// HistoricalReadConfig configures the historical read TCs.
+type HistoricalReadConfig struct {
+	Distribution     string
+	Depth            int           // the mean depth of recent, or the depth of fixed
+	CheckRatio       float64       // share of the balance and nonce reads at a block of the recorded snapshots
+	SnapshotInterval time.Duration // interval of sending the snapshot txs
+}
+
+// Parameters of the historical read TCs
+var (
+	historicalBlockParams = []TCParam{
+		{Name: "distribution", Default: HistoricalDistUniform, Usage: "distribution of the read blocks: uniform, recent (exponential depth with the mean of depth) or fixed (depth)"},
+		{Name: "depth", Default: 10000, Usage: "mean depth of the recent distribution, or the depth of the fixed distribution"},
+	}
+	historicalSnapshotParams = append(append([]TCParam{}, historicalBlockParams...),
+		TCParam{Name: "checkRatio", Default: 0.1, Usage: "share of the reads at a recorded snapshot, checked against the recorded value"},
+		TCParam{Name: "snapshotInterval", Default: 10 * time.Second, Usage: "interval of sending the snapshot txs, shared by readHistoricalBalance and readHistoricalNonce, so the first TC initialized decides it"},
+	)
+)
+
+// newHistoricalReadConfig reads the configuration of a historical read TC from its parameters.
+func newHistoricalReadConfig(config *TCConfig) HistoricalReadConfig {
+	hr := HistoricalReadConfig{
+		Distribution: config.StringParam("distribution"),
+		Depth:        config.IntParam("depth"),
+	}
+	if config.HasParam("checkRatio") {
+		hr.CheckRatio = config.Float64Param("checkRatio")
+		hr.SnapshotInterval = config.DurationParam("snapshotInterval")
+		if hr.CheckRatio < 0 || hr.CheckRatio > 1 || hr.SnapshotInterval <= 0 {
+			log.Fatalf("%v.checkRatio should be between 0 and 1, and %v.snapshotInterval should be positive", config.Name, config.Name)
+		}
+	}
+	if hr.Distribution != HistoricalDistUniform && hr.Distribution != HistoricalDistRecent && hr.Distribution != HistoricalDistFixed {
+		log.Fatalf("%v.distribution should be one of %v, %v and %v: %v", config.Name, HistoricalDistUniform, HistoricalDistRecent, HistoricalDistFixed, hr.Distribution)
+	} else if hr.Depth < 0 {
+		log.Fatalf("%v.depth should not be negative: %v", config.Name, hr.Depth)
+	}
+	return hr
+}
+
+// Historical read related variables
+var (
+	historicalHeadMu        sync.Mutex
+	historicalHead          uint64
+	historicalHeadUpdatedAt time.Time
+
+	snapshotsOnce    sync.Once
+	snapshotsMu      sync.Mutex
+	balanceSnapshots = snapshotRing{size: 10000}
+	nonceSnapshots   = snapshotRing{size: 10000}
+	snapshotsBatch   = 10 // txs sent per interval
+
+	// snapshotSender sends the snapshot txs, so that its nonce is only increased by them. It is funded by a test account.
+	snapshotSender     *account.Account
+	snapshotFunds      = new(big.Int).Mul(big.NewInt(10), big.NewInt(params.KAIA))
+	snapshotMinBalance = big.NewInt(params.KAIA)
+
+	// Block where the read API test contract is deployed. The code, the storage and the call are checked against it.
+	deployBlockOnce  sync.Once
+	deployBlock      uint64
+	deployBlockKnown bool
+	deployedCode     []byte
+)
+
+// stateSnapshot is the balance or the nonce of an account at a block, which follows from the txs sent by the slave.
+type stateSnapshot struct {
+	address common.Address
+	block   *big.Int
+	balance *big.Int
+	nonce   uint64
+}
+
+// snapshotRing keeps the latest size snapshots. snapshotsMu should be held.
+type snapshotRing struct {
+	size      int
+	snapshots []stateSnapshot
+	next      int
+}
+
+func (r *snapshotRing) add(snapshot stateSnapshot) {
+	if len(r.snapshots) < r.size {
+		r.snapshots = append(r.snapshots, snapshot)
+		return
+	}
+	r.snapshots[r.next] = snapshot
+	r.next = (r.next + 1) % r.size
+}
+
+// headBlockNumber returns the head block number, updated at most once a second.
+func headBlockNumber(ctx context.Context, cli *client.Client) (uint64, error) {
+	historicalHeadMu.Lock()
+	defer historicalHeadMu.Unlock()
+
+	if time.Since(historicalHeadUpdatedAt) > time.Second {
+		bn, err := cli.BlockNumber(ctx)
+		if err != nil {
+			return 0, err
+		}
+		historicalHead, historicalHeadUpdatedAt = bn.Uint64(), time.Now()
+	}
+	return historicalHead, nil
+}
+
+// pickHistoricalBlock returns a block of the configured distribution and its depth from the head.
+func pickHistoricalBlock(ctx context.Context, cli *client.Client, hr HistoricalReadConfig) (*big.Int, uint64, error) {
+	head, err := headBlockNumber(ctx, cli)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var depth uint64
+	switch hr.Distribution {
+	case HistoricalDistRecent:
+		depth = uint64(math.Min(rand.ExpFloat64()*float64(hr.Depth), float64(head)))
+	case HistoricalDistFixed:
+		depth = uint64(hr.Depth)
+	default:
+		depth = uint64(rand.Int63n(int64(head) + 1))
+	}
+	if depth > head {
+		depth = head
+	}
+	return new(big.Int).SetUint64(head - depth), depth, nil
+}
+
+// depthLabel returns the order of magnitude of a depth, so that the latency of the deep reads is reported separately.
+func depthLabel(depth uint64) string {
+	for _, bound := range []struct {
+		depth uint64
+		label string
+	}{{1e3, "<1K"}, {1e4, "<10K"}, {1e5, "<100K"}, {1e6, "<1M"}} {
+		if depth < bound.depth {
+			return bound.label
+		}
+	}
+	return ">=1M"
+}
+
+// publishHistoricalRead publishes the result of a historical read with the depth of the block in the name.
+func publishHistoricalRead(config *TCConfig, label string, elapsed int64, err error) {
+	name := fmt.Sprintf("%s (%s) to %s", config.Name, label, config.EndPoint)
+	if err == nil {
+		boomer.Events.Publish("request_success", "http", name, elapsed, int64(10))
+	} else {
+		boomer.Events.Publish("request_failure", "http", name, elapsed, err.Error())
+	}
+}
+
+// startStateSnapshots starts sending the snapshot txs and recording the state they leave on chain.
+// It is started once by the first TC which checks the snapshots.
+func startStateSnapshots(config *TCConfig, hr HistoricalReadConfig) {
+	snapshotsOnce.Do(func() {
+		snapshotSender = account.NewAccount(0)
+		go func() {
+			for range time.Tick(hr.SnapshotInterval) {
+				takeStateSnapshots(config)
+			}
+		}()
+	})
+}
+
+// takeStateSnapshots sends a random value from the snapshot sender to each of snapshotsBatch new accounts.
+// Once the txs are mined, the balance of a new account at the block of its tx is the value sent to it,
+// and the nonce of the sender at a block is the next of the last nonce sent in the block, which are recorded
+// without reading them back from the node.
+func takeStateSnapshots(config *TCConfig) {
+	ctx := context.Background()
+	cli := config.CliPool.Alloc().(*client.Client)
+	defer config.CliPool.Free(cli)
+
+	if balance, err := cli.BalanceAt(ctx, snapshotSender.GetAddress(), nil); err != nil || balance.Cmp(snapshotMinBalance) < 0 {
+		hash, _, err := config.AccGrp.GetAccountRandomly().TransferNewValueTransferTx(cli, snapshotSender, snapshotFunds)
+		if err == nil {
+			_, err = waitMined(cli, hash)
+		}
+		if err != nil {
+			log.Printf("Failed to fund the historical snapshot sender: %v", err)
+		}
+		return
+	}
+
+	type sentSnapshot struct {
+		hash  common.Hash
+		to    common.Address
+		value *big.Int
+		nonce uint64
+	}
+	sent := make([]sentSnapshot, 0, snapshotsBatch)
+	for i := 0; i < snapshotsBatch; i++ {
+		to := account.NewAccount(0)
+		value := big.NewInt(1 + rand.Int63n(1e6))
+		nonce := snapshotSender.GetNonce(cli)
+		hash, _, err := snapshotSender.TransferNewValueTransferTx(cli, to, value)
+		if err != nil {
+			break
+		}
+		sent = append(sent, sentSnapshot{hash: hash, to: to.GetAddress(), value: value, nonce: nonce})
+	}
+
+	nextNonces := make(map[uint64]uint64) // the next nonce of the sender at the blocks of the txs
+	for _, tx := range sent {
+		_, block, _, err := waitMinedIndex(cli, tx.hash)
+		if err != nil {
+			continue
+		}
+		if tx.nonce+1 > nextNonces[block.Uint64()] {
+			nextNonces[block.Uint64()] = tx.nonce + 1
+		}
+		snapshotsMu.Lock()
+		balanceSnapshots.add(stateSnapshot{address: tx.to, block: block, balance: tx.value})
+		snapshotsMu.Unlock()
+	}
+	snapshotsMu.Lock()
+	for block, nonce := range nextNonces {
+		nonceSnapshots.add(stateSnapshot{address: snapshotSender.GetAddress(), block: new(big.Int).SetUint64(block), nonce: nonce})
+	}
+	snapshotsMu.Unlock()
+}
+
+// randomSnapshot returns a recorded snapshot of the ring with the probability of the check ratio, or nil.
+func randomSnapshot(ring *snapshotRing, checkRatio float64) *stateSnapshot {
+	if rand.Float64() >= checkRatio {
+		return nil
+	}
+	snapshotsMu.Lock()
+	defer snapshotsMu.Unlock()
+	if len(ring.snapshots) == 0 {
+		return nil
+	}
+	snapshot := ring.snapshots[rand.Intn(len(ring.snapshots))]
+	return &snapshot
+}
+
+// findDeployBlock finds the block where the read API test contract is deployed by the binary search of its code.
+// The code, the storage and the call are not checked if the node does not have the state of the old blocks.
+func findDeployBlock(config *TCConfig) {
+	deployBlockOnce.Do(func() {
+		ctx := context.Background()
+		cli := config.CliPool.Alloc().(*client.Client)
+		defer config.CliPool.Free(cli)
+
+		contractAddr := config.SmartContractAccounts[account.ContractReadApiCallContract].GetAddress()
+		var lo, hi uint64
+		head, err := cli.BlockNumber(ctx)
+		if err == nil {
+			hi = head.Uint64()
+			deployedCode, err = cli.CodeAt(ctx, contractAddr, head)
+		}
+		for err == nil && lo < hi {
+			mid := (lo + hi) / 2
+			var code []byte
+			if code, err = cli.CodeAt(ctx, contractAddr, new(big.Int).SetUint64(mid)); len(code) > 0 {
+				hi = mid
+			} else {
+				lo = mid + 1
+			}
+		}
+		if err != nil || len(deployedCode) == 0 {
+			log.Printf("Failed to find the deploy block of the read API test contract, the historical code, storage and call are not checked. err=%v", err)
+			return
+		}
+		deployBlock, deployBlockKnown = lo, true
+		log.Printf("The read API test contract is deployed at the block %d", deployBlock)
+	})
+}
+
+// RunHistoricalBalanceTC creates a closure for historical balance test case.
+// Some of the reads are at the block of a snapshot tx and checked against the value it sent to a new account.
+func RunHistoricalBalanceTC(config *TCConfig) func() {
+	hr := newHistoricalReadConfig(config)
+	startStateSnapshots(config, hr)
+
+	return func() {
+		ctx := context.Background()
+		cli := config.CliPool.Alloc().(*client.Client)
+		defer config.CliPool.Free(cli)
+
+		if snapshot := randomSnapshot(&balanceSnapshots, hr.CheckRatio); snapshot != nil {
+			start := boomer.Now()
+			balance, err := cli.BalanceAt(ctx, snapshot.address, snapshot.block)
+			elapsed := boomer.Now() - start
+			if err == nil && balance.Cmp(snapshot.balance) != 0 {
+				err = fmt.Errorf("wrong balance at the block %v: %v, recorded: %v", snapshot.block, balance, snapshot.balance)
+			}
+			publishHistoricalRead(config, "snapshot", elapsed, err)
+			return
+		}
+
+		bn, depth, err := pickHistoricalBlock(ctx, cli, hr)
+		if err != nil {
+			publishHistoricalRead(config, "head", 0, err)
+			return
+		}
+		start := boomer.Now()
+		_, err = cli.BalanceAt(ctx, config.AccGrp.GetAccountRandomly().GetAddress(), bn)
+		elapsed := boomer.Now() - start
+		publishHistoricalRead(config, "depth "+depthLabel(depth), elapsed, err)
+	}
+}
+
+// RunHistoricalNonceTC creates a closure for historical nonce test case.
+// Some of the reads are at the block of a snapshot tx and checked against the nonces of the snapshot sender.
+func RunHistoricalNonceTC(config *TCConfig) func() {
+	hr := newHistoricalReadConfig(config)
+	startStateSnapshots(config, hr)
+
+	return func() {
+		ctx := context.Background()
+		cli := config.CliPool.Alloc().(*client.Client)
+		defer config.CliPool.Free(cli)
+
+		if snapshot := randomSnapshot(&nonceSnapshots, hr.CheckRatio); snapshot != nil {
+			start := boomer.Now()
+			nonce, err := cli.NonceAt(ctx, snapshot.address, snapshot.block)
+			elapsed := boomer.Now() - start
+			if err == nil && nonce != snapshot.nonce {
+				err = fmt.Errorf("wrong nonce at the block %v: %d, recorded: %d", snapshot.block, nonce, snapshot.nonce)
+			}
+			publishHistoricalRead(config, "snapshot", elapsed, err)
+			return
+		}
+
+		bn, depth, err := pickHistoricalBlock(ctx, cli, hr)
+		if err != nil {
+			publishHistoricalRead(config, "head", 0, err)
+			return
+		}
+		start := boomer.Now()
+		_, err = cli.NonceAt(ctx, config.AccGrp.GetAccountRandomly().GetAddress(), bn)
+		elapsed := boomer.Now() - start
+		publishHistoricalRead(config, "depth "+depthLabel(depth), elapsed, err)
+	}
+}
+
+// RunHistoricalCodeTC creates a closure for historical code test case.
+// The code of the read API test contract should be empty before the deploy block, and the deployed one after.
+func RunHistoricalCodeTC(config *TCConfig) func() {
+	hr := newHistoricalReadConfig(config)
+	findDeployBlock(config)
+
+	return func() {
+		ctx := context.Background()
+		cli := config.CliPool.Alloc().(*client.Client)
+		defer config.CliPool.Free(cli)
+
+		bn, depth, err := pickHistoricalBlock(ctx, cli, hr)
+		if err != nil {
+			publishHistoricalRead(config, "head", 0, err)
+			return
+		}
+		contractAddr := config.SmartContractAccounts[account.ContractReadApiCallContract].GetAddress()
+		start := boomer.Now()
+		code, err := cli.CodeAt(ctx, contractAddr, bn)
+		elapsed := boomer.Now() - start
+
+		if err == nil && deployBlockKnown {
+			if deployed := bn.Uint64() >= deployBlock; deployed && !bytes.Equal(code, deployedCode) {
+				err = fmt.Errorf("wrong code at the block %v after the deploy block %d", bn, deployBlock)
+			} else if !deployed && len(code) > 0 {
+				err = fmt.Errorf("code at the block %v before the deploy block %d", bn, deployBlock)
+			}
+		}
+		publishHistoricalRead(config, "depth "+depthLabel(depth), elapsed, err)
+	}
+}
+
+// RunHistoricalStorageAtTC creates a closure for historical storage at test case.
+// The slot 0 of the read API test contract should be 0 before the deploy block, and retValOfStorageAt after.
+func RunHistoricalStorageAtTC(config *TCConfig) func() {
+	hr := newHistoricalReadConfig(config)
+	findDeployBlock(config)
+
+	return func() {
+		ctx := context.Background()
+		cli := config.CliPool.Alloc().(*client.Client)
+		defer config.CliPool.Free(cli)
+
+		bn, depth, err := pickHistoricalBlock(ctx, cli, hr)
+		if err != nil {
+			publishHistoricalRead(config, "head", 0, err)
+			return
+		}
+		contractAddr := config.SmartContractAccounts[account.ContractReadApiCallContract].GetAddress()
+		start := boomer.Now()
+		ret, err := cli.StorageAt(ctx, contractAddr, common.Hash{}, bn)
+		elapsed := boomer.Now() - start
+
+		if err == nil && deployBlockKnown {
+			answer := new(big.Int)
+			if bn.Uint64() >= deployBlock {
+				answer = retValOfStorageAt
+			}
+			if value := new(big.Int).SetBytes(ret); value.Cmp(answer) != 0 {
+				err = fmt.Errorf("wrong storage value at the block %v: %v, answer: %v", bn, value, answer)
+			}
+		}
+		publishHistoricalRead(config, "depth "+depthLabel(depth), elapsed, err)
+	}
+}
+
+// RunHistoricalCallTC creates a closure for historical call test case.
+// The call of the read API test contract should return nothing before the deploy block, and retValOfCall after.
+func RunHistoricalCallTC(config *TCConfig) func() {
+	hr := newHistoricalReadConfig(config)
+	findDeployBlock(config)
+	parsedABI, err := abi.JSON(strings.NewReader(getABI))
+	if err != nil {
+		log.Fatalf("failed to abi.JSON: %v", err)
+	}
+
+	return func() {
+		ctx := context.Background()
+		cli := config.CliPool.Alloc().(*client.Client)
+		defer config.CliPool.Free(cli)
+
+		bn, depth, err := pickHistoricalBlock(ctx, cli, hr)
+		if err != nil {
+			publishHistoricalRead(config, "head", 0, err)
+			return
+		}
+		fromAccount := config.AccGrp.GetAccountRandomly().GetAddress()
+		contractAddr := config.SmartContractAccounts[account.ContractReadApiCallContract].GetAddress()
+		callMsg := kaia.CallMsg{
+			From: fromAccount,
+			To:   &contractAddr,
+			Data: account.TestContractInfos[account.ContractReadApiCallContract].GenData(fromAccount, big.NewInt(0)),
+		}
+		start := boomer.Now()
+		result, err := cli.CallContract(ctx, callMsg, bn)
+		elapsed := boomer.Now() - start
+
+		if err == nil && deployBlockKnown {
+			if bn.Uint64() < deployBlock {
+				if len(result) > 0 {
+					err = fmt.Errorf("call result at the block %v before the deploy block %d", bn, deployBlock)
+				}
+			} else {
+				var ret *big.Int
+				if err = parsedABI.UnpackIntoInterface(&ret, "get", result); err == nil && ret.Cmp(retValOfCall) != 0 {
+					err = errors.New("wrong call: " + ret.String() + ", answer: " + retValOfCall.String())
+				}
+			}
+		}
+		publishHistoricalRead(config, "depth "+depthLabel(depth), elapsed, err)
+	}
+}
@@ -0,0 +1,99 @@
+package testcase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kaiachain/kaia-load-tester/klayslave/account"
+	"github.com/kaiachain/kaia/blockchain"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/kerrors"
+	"github.com/kaiachain/kaia/networks/rpc"
+	"github.com/kaiachain/kaia/rlp"
+	"github.com/myzhan/boomer"
+)
+
+// InvalidTxGenFunc generates an RLP encoded tx which the node should reject. feePayer is a test account for the fee delegated txs.
+type InvalidTxGenFunc = func(feePayer *account.Account) []byte
+
+// RunBaseInvalidTx creates a closure which sends an invalid tx by sendRawTransaction.
+// It succeeds only if the node rejects the tx with the expected error, so that a change of the validation or its error message fails it.
+func RunBaseInvalidTx(config *TCConfig, genTx InvalidTxGenFunc, expected error) func() {
+	return func() {
+		ctx := context.Background()
+		rpcCli := config.RpcCliPool.Alloc().(*rpc.Client)
+		defer config.RpcCliPool.Free(rpcCli)
+
+		raw := genTx(config.AccGrp.GetAccountRandomly())
+
+		var hash common.Hash
+		start := boomer.Now()
+		err := rpcCli.CallContext(ctx, &hash, "kaia_sendRawTransaction", hexutil.Encode(raw))
+		elapsed := boomer.Now() - start
+
+		if err == nil {
+			boomer.Events.Publish("request_failure", "http", config.Name+" to "+config.EndPoint, elapsed, fmt.Sprintf("the invalid tx is accepted: %v", hash.Hex()))
+		} else if !strings.Contains(err.Error(), expected.Error()) {
+			boomer.Events.Publish("request_failure", "http", config.Name+" to "+config.EndPoint, elapsed, fmt.Sprintf("unexpected error: %v, expected: %v", err, expected))
+		} else {
+			boomer.Events.Publish("request_success", "http", config.Name+" to "+config.EndPoint, elapsed, int64(10))
+		}
+	}
+}
+
+func RunInvalidChainIDTC(config *TCConfig) func() {
+	genTx := func(_ *account.Account) []byte {
+		return account.GenInvalidChainIDTx()
+	}
+	return RunBaseInvalidTx(config, genTx, blockchain.ErrInvalidChainId)
+}
+
+func RunInvalidSignatureTC(config *TCConfig) func() {
+	genTx := func(_ *account.Account) []byte {
+		return account.GenInvalidSignatureTx()
+	}
+	return RunBaseInvalidTx(config, genTx, types.ErrSender(types.ErrInvalidAccountKey))
+}
+
+func RunInvalidFeePayerSignatureTC(config *TCConfig) func() {
+	return RunBaseInvalidTx(config, account.GenInvalidFeePayerSignatureTx, types.ErrFeePayer(types.ErrInvalidAccountKey))
+}
+
+func RunUnderpricedTxTC(config *TCConfig) func() {
+	genTx := func(_ *account.Account) []byte {
+		return account.GenUnderpricedTx()
+	}
+	return RunBaseInvalidTx(config, genTx, blockchain.ErrGasPriceBelowBaseFee)
+}
+
+func RunOversizedTxTC(config *TCConfig) func() {
+	genTx := func(_ *account.Account) []byte {
+		return account.GenOversizedTx()
+	}
+	return RunBaseInvalidTx(config, genTx, blockchain.ErrOversizedData)
+}
+
+func RunOverGasLimitTxTC(config *TCConfig) func() {
+	return RunBaseInvalidTx(config, account.GenOverGasLimitTx, blockchain.ErrInsufficientFundsFeePayer)
+}
+
+func RunInsufficientBalanceTxTC(config *TCConfig) func() {
+	genTx := func(_ *account.Account) []byte {
+		return account.GenInsufficientBalanceTx()
+	}
+	return RunBaseInvalidTx(config, genTx, blockchain.ErrInsufficientFundsFrom)
+}
+
+func RunInvalidAccountKeyUpdateTC(config *TCConfig) func() {
+	return RunBaseInvalidTx(config, account.GenInvalidAccountKeyUpdateTx, kerrors.ErrUnsatisfiableThreshold)
+}
+
+func RunMalformedRLPTxTC(config *TCConfig) func() {
+	genTx := func(_ *account.Account) []byte {
+		return account.GenMalformedRLPTx()
+	}
+	return RunBaseInvalidTx(config, genTx, rlp.ErrValueTooLarge)
+}
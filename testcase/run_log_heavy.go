@@ -0,0 +1,122 @@
+package testcase
+
+import (
+	"log"
+	"math/big"
+	"math/bits"
+	"sync/atomic"
+	"time"
+
+	"github.com/kaiachain/kaia-load-tester/klayslave/account"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/client"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/rlp"
+)
+
+// LogHeavyConfig configures the events emitted by every tx of logHeavyTC.
+type LogHeavyConfig struct {
+	Events    int  // events per tx
+	Indexed   int  // indexed arguments per event, up to 3, or 4 if anonymous
+	DataSize  int  // bytes of the non-indexed data per event
+	Anonymous bool // emit the events without the signature topic
+}
+
+// logHeavyConfig is set from the parameters of logHeavyTC when the TC is initialized.
+var logHeavyConfig = LogHeavyConfig{Events: 10, Indexed: 3, DataSize: 256}
+
+// logHeavyTxs is the number of the txs sent by logHeavyTC.
+var logHeavyTxs uint64
+
+// setLogHeavyConfig sets the configuration of logHeavyTC from its parameters.
+func setLogHeavyConfig(config *TCConfig) {
+	lh := LogHeavyConfig{
+		Events:    config.IntParam("events"),
+		Indexed:   config.IntParam("indexed"),
+		DataSize:  config.IntParam("dataSize"),
+		Anonymous: config.BoolParam("anonymous"),
+	}
+	if lh.Events <= 0 || lh.DataSize < 0 {
+		log.Fatalf("%v.events should be positive, and %v.dataSize should not be negative", config.Name, config.Name)
+	} else if lh.Indexed < 0 || lh.Indexed > 4 || (lh.Indexed > 3 && !lh.Anonymous) {
+		log.Fatalf("%v.indexed should be between 0 and 3, or 4 with anonymous: %v", config.Name, lh.Indexed)
+	}
+	gasLimit := uint64(5000000) // that of TransferNewSmartContractExecutionTx
+	if override := config.Uint64Param(gasLimitParam.Name); override != 0 {
+		gasLimit = override
+	}
+	// LOG costs 375 + 375 per topic + 8 per byte, and the data is also in the calldata at 16 per byte
+	if gas := lh.Events*(375*(lh.Indexed+2)+8*lh.DataSize) + 16*lh.DataSize; uint64(gas) > gasLimit*9/10 {
+		log.Fatalf("%v needs about %v gas per tx, which exceeds the gas limit of %v. Reduce events or dataSize", config.Name, gas, gasLimit)
+	}
+	logHeavyConfig = lh
+}
+
+// logHeavyReceipt returns the size of the stored receipt and the number of the bloom bits set by a tx of logHeavyTC.
+// The receipt is built with the same shape of the logs as the mined one and encoded as the node stores it.
+func logHeavyReceipt(contract common.Address) (int, int) {
+	topics := logHeavyConfig.Indexed
+	if !logHeavyConfig.Anonymous {
+		topics++
+	}
+
+	receipt := types.NewReceipt(types.ReceiptStatusSuccessful, common.BytesToHash([]byte{1}), 5000000)
+	for i := 0; i < logHeavyConfig.Events; i++ {
+		l := &types.Log{
+			Address:     contract,
+			Topics:      make([]common.Hash, topics),
+			Data:        make([]byte, logHeavyConfig.DataSize),
+			BlockNumber: 1 << 32,
+			TxHash:      receipt.TxHash,
+			Index:       uint(i),
+		}
+		for j := range l.Topics {
+			l.Topics[j] = common.BytesToHash(big.NewInt(int64(i*4 + j + 1)).Bytes())
+		}
+		receipt.Logs = append(receipt.Logs, l)
+	}
+	receipt.Bloom = types.BytesToBloom(types.LogsBloom(receipt.Logs).Bytes())
+
+	encoded, err := rlp.EncodeToBytes((*types.ReceiptForStorage)(receipt))
+	if err != nil {
+		log.Fatalf("Failed to encode the receipt of logHeavyTC: %v", err)
+	}
+	bloomBits := 0
+	for _, b := range receipt.Bloom.Bytes() {
+		bloomBits += bits.OnesCount8(b)
+	}
+	return len(encoded), bloomBits
+}
+
+// reportLogHeavyLoad periodically logs the receipt and bloom storage produced by the txs of logHeavyTC once mined.
+func reportLogHeavyLoad(receiptSize, bloomBits int) {
+	var last uint64
+	for range time.Tick(10 * time.Second) {
+		txs := atomic.LoadUint64(&logHeavyTxs)
+		if txs == last {
+			continue
+		}
+		last = txs
+		log.Printf("logHeavyTC: %d txs sent, %d logs of %d topics and %d bytes each, %.1f MB of receipts (%d bytes per receipt), %d of %d bloom bits set per receipt",
+			txs, txs*uint64(logHeavyConfig.Events), logHeavyConfig.Indexed, logHeavyConfig.DataSize,
+			float64(txs*uint64(receiptSize))/1e6, receiptSize, bloomBits, types.BloomByteLength*8)
+	}
+}
+
+// RunLogHeavyTC creates a closure for log heavy test case.
+// Every tx emits LogHeavyConfig.Events events, which stress the log indexing and the receipt storage of the node.
+func RunLogHeavyTC(config *TCConfig) func() {
+	setLogHeavyConfig(config)
+	receiptSize, bloomBits := logHeavyReceipt(config.SmartContractAccounts[account.ContractLogHeavy].GetAddress())
+	go reportLogHeavyLoad(receiptSize, bloomBits)
+
+	txFunc := func(cli *client.Client, from *account.Account, to *account.Account) (interface{}, *big.Int, error) {
+		data := account.GenLogHeavyData(logHeavyConfig.Events, logHeavyConfig.Indexed, logHeavyConfig.DataSize, logHeavyConfig.Anonymous)
+		tx, gasPrice, err := from.TransferNewSmartContractExecutionTx(cli, to, nil, data)
+		if err == nil {
+			atomic.AddUint64(&logHeavyTxs, 1)
+		}
+		return tx, gasPrice, err
+	}
+	return RunBaseWithContract(config, txFunc)
+}
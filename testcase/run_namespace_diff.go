@@ -0,0 +1,162 @@
+package testcase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand"
+	"strings"
+	"sync/atomic"
+
+	"github.com/kaiachain/kaia/client"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/networks/rpc"
+	"github.com/myzhan/boomer"
+	"github.com/tidwall/gjson"
+)
+
+// RPC namespaces of the read TCs
+const (
+	NamespaceKaia = "kaia"
+	NamespaceKlay = "klay" // the alias of kaia kept for the old dApps
+	NamespaceEth  = "eth"
+)
+
+// readNamespaceParam is the parameter of the read TCs.
+var readNamespaceParam = TCParam{Name: "namespace", Default: NamespaceKaia, Usage: "RPC namespace: kaia, klay or eth. The kaia-only APIs, e.g. getAccount, are called in kaia with eth"}
+
+// Fields compared by readNamespaceDiff. The quantities are compared by their values, the others as strings.
+var (
+	diffBlockFields   = []string{"number", "hash", "parentHash", "stateRoot", "transactionsRoot", "receiptsRoot", "logsBloom", "timestamp", "gasUsed", "baseFeePerGas", "transactions"}
+	diffTxFields      = []string{"hash", "blockHash", "blockNumber", "transactionIndex", "from", "to", "nonce", "value", "gas", "input"}
+	diffReceiptFields = []string{"transactionHash", "blockHash", "blockNumber", "transactionIndex", "status", "gasUsed", "contractAddress", "logsBloom", "logs.#.address", "logs.#.topics", "logs.#.data"}
+	quantityFields    = map[string]bool{"number": true, "timestamp": true, "gasUsed": true, "baseFeePerGas": true, "blockNumber": true, "transactionIndex": true, "nonce": true, "value": true, "gas": true, "status": true}
+
+	diffMismatches       uint64
+	diffMismatchLogLimit = uint64(100)
+)
+
+func isNamespace(ns string) bool {
+	return ns == NamespaceKaia || ns == NamespaceKlay || ns == NamespaceEth
+}
+
+// readNamespace returns the namespace parameter of the read TC.
+func readNamespace(config *TCConfig) string {
+	ns := config.StringParam(readNamespaceParam.Name)
+	if !isNamespace(ns) {
+		log.Fatalf("%v.%v should be one of kaia, klay and eth: %v", config.Name, readNamespaceParam.Name, ns)
+	}
+	return ns
+}
+
+// readMethod returns the method of the read TCs in the namespace.
+func readMethod(ns, method string) string {
+	return ns + "_" + method
+}
+
+// kaiaReadMethod returns the method of the read TCs which only the kaia namespace has, e.g. getAccount.
+// It is called in the klay namespace if the namespace is klay, and in the kaia namespace otherwise.
+func kaiaReadMethod(ns, method string) string {
+	if ns == NamespaceKlay {
+		return NamespaceKlay + "_" + method
+	}
+	return NamespaceKaia + "_" + method
+}
+
+// diffCall calls the method in both of the diff namespaces and returns the results.
+func diffCall(config *TCConfig, rpcCli *rpc.Client, namespaces [2]string, method string, args ...interface{}) ([2]string, error) {
+	var results [2]string
+	for i, ns := range namespaces {
+		name := fmt.Sprintf("%s (%s_%s) to %s", config.Name, ns, method, config.EndPoint)
+
+		start := boomer.Now()
+		var result json.RawMessage
+		err := rpcCli.CallContext(context.Background(), &result, ns+"_"+method, args...)
+		elapsed := boomer.Now() - start
+		if err != nil {
+			boomer.Events.Publish("request_failure", "http", name, elapsed, err.Error())
+			return results, err
+		}
+		boomer.Events.Publish("request_success", "http", name, elapsed, int64(len(result)))
+		results[i] = string(result)
+	}
+	return results, nil
+}
+
+// diffFields returns the fields whose values differ between the results of the two namespaces.
+func diffFields(results [2]string, fields []string) []string {
+	var mismatches []string
+	for _, field := range fields {
+		a, b := gjson.Get(results[0], field), gjson.Get(results[1], field)
+		if quantityFields[field] {
+			x, errX := hexutil.DecodeBig(a.String())
+			y, errY := hexutil.DecodeBig(b.String())
+			if (errX == nil) == (errY == nil) && (errX != nil || x.Cmp(y) == 0) {
+				continue
+			}
+		} else if strings.EqualFold(a.Raw, b.Raw) {
+			continue
+		}
+		mismatches = append(mismatches, fmt.Sprintf("%s: %s vs %s", field, a.Raw, b.Raw))
+	}
+	return mismatches
+}
+
+// RunReadNamespaceDiffTC creates a closure for read namespace diff test case.
+// It reads a random block, and a random tx of the block and its receipt in the two namespaces, and compares them.
+// A semantic mismatch is reported as a failure of "<tc> mismatch" and logged.
+func RunReadNamespaceDiffTC(config *TCConfig) func() {
+	var ns [2]string
+	if namespaces := config.ListParam("namespaces"); len(namespaces) != 2 {
+		log.Fatalf("%v.namespaces should be two namespaces separated by comma: %v", config.Name, namespaces)
+	} else {
+		for i := range ns {
+			if ns[i] = namespaces[i]; !isNamespace(ns[i]) {
+				log.Fatalf("%v.namespaces should be some of kaia, klay and eth: %v", config.Name, ns[i])
+			}
+		}
+	}
+	return func() {
+		ctx := context.Background()
+		cli := config.CliPool.Alloc().(*client.Client)
+		defer config.CliPool.Free(cli)
+		rpcCli := config.RpcCliPool.Alloc().(*rpc.Client)
+		defer config.RpcCliPool.Free(rpcCli)
+
+		bn := getRandomBlockNumber(cli, ctx)
+		start := boomer.Now()
+
+		blocks, err := diffCall(config, rpcCli, ns, "getBlockByNumber", hexutil.EncodeBig(bn), false)
+		if err != nil {
+			return
+		}
+		mismatches := diffFields(blocks, diffBlockFields)
+
+		if txs := gjson.Get(blocks[0], "transactions").Array(); len(txs) > 0 {
+			hash := txs[rand.Intn(len(txs))].String()
+			if results, err := diffCall(config, rpcCli, ns, "getTransactionByHash", hash); err == nil {
+				mismatches = append(mismatches, diffFields(results, diffTxFields)...)
+			}
+			if results, err := diffCall(config, rpcCli, ns, "getTransactionReceipt", hash); err == nil {
+				mismatches = append(mismatches, diffFields(results, diffReceiptFields)...)
+			}
+		}
+		elapsed := boomer.Now() - start
+
+		name := config.Name + " mismatch to " + config.EndPoint
+		if len(mismatches) == 0 {
+			boomer.Events.Publish("request_success", "http", name, elapsed, int64(10))
+			return
+		}
+		// the failure message has only the fields, so that the same mismatches are counted together
+		fields := make([]string, len(mismatches))
+		for i, mismatch := range mismatches {
+			fields[i] = strings.SplitN(mismatch, ":", 2)[0]
+		}
+		boomer.Events.Publish("request_failure", "http", name, elapsed, fmt.Sprintf("%s and %s differ in %s", ns[0], ns[1], strings.Join(fields, ", ")))
+		if n := atomic.AddUint64(&diffMismatches, 1); n <= diffMismatchLogLimit {
+			log.Printf("Mismatch between %s and %s at the block %v:\n  %s", ns[0], ns[1], bn, strings.Join(mismatches, "\n  "))
+		}
+	}
+}
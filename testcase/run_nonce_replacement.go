@@ -0,0 +1,148 @@
+package testcase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"math/rand"
+	"time"
+
+	"github.com/kaiachain/kaia-load-tester/klayslave/account"
+	"github.com/kaiachain/kaia/client"
+	"github.com/kaiachain/kaia/common"
+	"github.com/myzhan/boomer"
+)
+
+// maxNonceGap is the max nonce gap of futureNonceTC, under the default per-account limit of the non-executable txs in the txpool.
+const maxNonceGap = 64
+
+// RunFutureNonceTC creates a closure which sends a tx with a nonce gap, so that it is queued in the txpool, and fills the gap later.
+// The send of the txs is published as "<tc> to <endpoint>", excluding the fill delay,
+// and the time from filling the gap to the queued tx being mined as "<tc> promoted".
+func RunFutureNonceTC(config *TCConfig) func() {
+	minGap, maxGap := config.IntParam("minGap"), config.IntParam("maxGap")
+	if minGap < 1 || maxGap < minGap || maxGap >= maxNonceGap {
+		log.Fatalf("%v gap should be 1 <= minGap <= maxGap < %v: minGap %v, maxGap %v", config.Name, maxNonceGap, minGap, maxGap)
+	}
+	fillDelayMs := config.IntParam("fillDelayMs")
+	if fillDelayMs < 0 {
+		log.Fatalf("%v.fillDelayMs should not be negative: %v", config.Name, fillDelayMs)
+	}
+	fillDelay := time.Duration(fillDelayMs) * time.Millisecond
+	startMinedWatcher(config.EndPoint)
+
+	return func() {
+		cli := config.CliPool.Alloc().(*client.Client)
+		defer config.CliPool.Free(cli)
+
+		from := config.AccGrp.GetAccountRandomly()
+		to := config.AccGrp.GetAccountRandomly()
+		gap := minGap + rand.Intn(maxGap-minGap+1)
+
+		start := boomer.Now()
+		futureTx, slept, filledAt, err := from.TransferNonceGapTxs(cli, to, gap, fillDelay)
+		elapsed := boomer.Now() - start - slept.Milliseconds()
+
+		if err != nil {
+			boomer.Events.Publish("request_failure", "http", config.Name+" to "+config.EndPoint, elapsed, err.Error())
+			return
+		}
+		onTxSent(config.Name, futureTx)
+		boomer.Events.Publish("request_success", "http", config.Name+" to "+config.EndPoint, elapsed, int64(10))
+
+		queued := watchMined(func(cli *client.Client) {
+			mined, err := waitMined(cli, futureTx.Hash())
+			if err != nil {
+				boomer.Events.Publish("request_failure", "http", config.Name+" promoted", time.Since(filledAt).Milliseconds(), fmt.Sprintf("the queued tx is not promoted: %v", err))
+				return
+			}
+			boomer.Events.Publish("request_success", "http", config.Name+" promoted", mined.Sub(filledAt).Milliseconds(), int64(gap))
+		})
+		if !queued {
+			boomer.Events.Publish("request_failure", "http", config.Name+" promoted", 0, "the mined tx watcher queue is full")
+		}
+	}
+}
+
+// RunBaseReplacement creates a closure which sends a value transfer and replaces it by another tx of the same nonce.
+// The mined one of the two is published as "<tc> replacement won" or "<tc> original won",
+// with the time from sending the replacement to the tx being mined, which shows how fast the replacement propagates to the proposer.
+func RunBaseReplacement(config *TCConfig, kind account.ReplacementKind, priceBumpPercent int) func() {
+	maxValue := config.maxValue()
+	startMinedWatcher(config.EndPoint)
+
+	return func() {
+		cli := config.CliPool.Alloc().(*client.Client)
+		defer config.CliPool.Free(cli)
+
+		from := config.AccGrp.GetAccountRandomly()
+		to := config.AccGrp.GetAccountRandomly()
+		value := big.NewInt(int64(rand.Intn(maxValue + 1)))
+
+		start := boomer.Now()
+		original, replacement, replaceErr, err := from.TransferReplacementTxs(cli, to, value, kind, priceBumpPercent)
+		elapsed := boomer.Now() - start
+		replacedAt := time.Now()
+
+		if err != nil {
+			boomer.Events.Publish("request_failure", "http", config.Name+" to "+config.EndPoint, elapsed, err.Error())
+			return
+		}
+		boomer.Events.Publish("request_success", "http", config.Name+" to "+config.EndPoint, elapsed, int64(10))
+		if replaceErr != nil {
+			boomer.Events.Publish("request_failure", "http", config.Name+" replacement rejected", elapsed, replaceErr.Error())
+		}
+
+		queued := watchMined(func(cli *client.Client) {
+			mined, winner, err := waitFirstMined(cli, original.Hash(), replacement.Hash())
+			if err != nil {
+				boomer.Events.Publish("request_failure", "http", config.Name+" replaced", time.Since(replacedAt).Milliseconds(), err.Error())
+				return
+			}
+			name := config.Name + " replacement won"
+			if winner == original.Hash() {
+				name = config.Name + " original won"
+			}
+			boomer.Events.Publish("request_success", "http", name, mined.Sub(replacedAt).Milliseconds(), int64(10))
+		})
+		if !queued {
+			boomer.Events.Publish("request_failure", "http", config.Name+" replaced", 0, "the mined tx watcher queue is full")
+		}
+	}
+}
+
+func RunGasPriceReplacementTC(config *TCConfig) func() {
+	priceBumpPercent := config.IntParam("priceBumpPercent")
+	if priceBumpPercent <= 0 {
+		log.Fatalf("%v.priceBumpPercent should be positive: %v", config.Name, priceBumpPercent)
+	}
+	return RunBaseReplacement(config, account.ReplaceWithGasPrice, priceBumpPercent)
+}
+
+func RunCancelReplacementTC(config *TCConfig) func() {
+	return RunBaseReplacement(config, account.ReplaceWithCancel, 0)
+}
+
+// waitMined waits until the tx is mined and returns the time when its receipt is found.
+func waitMined(cli *client.Client, hash common.Hash) (time.Time, error) {
+	mined, _, err := waitFirstMined(cli, hash)
+	return mined, err
+}
+
+// waitFirstMined waits until one of the txs is mined, and returns the time when its receipt is found and its hash.
+// The receipts are polled as the receipt verifier does.
+func waitFirstMined(cli *client.Client, hashes ...common.Hash) (time.Time, common.Hash, error) {
+	start := time.Now()
+	for {
+		for _, hash := range hashes {
+			if receipt, err := cli.TransactionReceipt(context.Background(), hash); err == nil && receipt != nil {
+				return time.Now(), hash, nil
+			}
+		}
+		if time.Since(start) > verifyTimeout {
+			return time.Time{}, common.Hash{}, fmt.Errorf("no tx is mined in %v", verifyTimeout)
+		}
+		time.Sleep(verifyPollInterval)
+	}
+}
@@ -12,11 +12,11 @@ import (
 	"sync"
 	"time"
 
-	kaia "github.com/kaiachain/kaia"
 	"github.com/kaiachain/kaia-load-tester/klayslave/account"
 	"github.com/kaiachain/kaia/accounts/abi"
 	"github.com/kaiachain/kaia/client"
 	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
 	"github.com/kaiachain/kaia/networks/rpc"
 	"github.com/myzhan/boomer"
 	"github.com/tidwall/gjson"
@@ -29,6 +29,9 @@ var (
 
 	retValOfCall      = big.NewInt(4)
 	retValOfStorageAt = big.NewInt(4)
+
+	// getABI is the ABI of the get function of the read API test contract
+	getABI = `[{"inputs":[],"name":"get","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`
 )
 
 func sendBoomerEvent(tcName string, logString string, elapsed int64, err error, endpoint string) {
@@ -60,59 +63,71 @@ func getRandomBlockNumber(cli *client.Client, ctx context.Context) *big.Int {
 
 // RunGasPrice creates a closure for gas price test case
 func RunGasPrice(config *TCConfig) func() {
+	ns := readNamespace(config)
 	return func() {
 		ctx := context.Background()
-		cli := config.CliPool.Alloc().(*client.Client)
-		defer config.CliPool.Free(cli)
+		rpcCli := config.RpcCliPool.Alloc().(*rpc.Client)
+		defer config.RpcCliPool.Free(rpcCli)
 
 		start := boomer.Now()
-		_, err := cli.SuggestGasPrice(ctx)
+		var gasPrice hexutil.Big
+		err := rpcCli.CallContext(ctx, &gasPrice, readMethod(ns, "gasPrice"))
 		elapsed := boomer.Now() - start
-		sendBoomerEvent("readGasPrice", "Failed to call klay_gasPrice", elapsed, err, config.EndPoint)
+		sendBoomerEvent("readGasPrice", "Failed to call gasPrice", elapsed, err, config.EndPoint)
 	}
 }
 
 // RunBlockNumber creates a closure for block number test case
 func RunBlockNumber(config *TCConfig) func() {
+	ns := readNamespace(config)
 	return func() {
 		ctx := context.Background()
-		cli := config.CliPool.Alloc().(*client.Client)
-		defer config.CliPool.Free(cli)
+		rpcCli := config.RpcCliPool.Alloc().(*rpc.Client)
+		defer config.RpcCliPool.Free(rpcCli)
 
 		start := boomer.Now()
 
-		bn, err := cli.BlockNumber(ctx)
-		if err == nil && bn.Cmp(big.NewInt(0)) != 1 {
-			err = errors.New("wrong block number: 0x" + bn.Text(16) + ", answer: smaller than 0")
+		var bn hexutil.Big
+		err := rpcCli.CallContext(ctx, &bn, readMethod(ns, "blockNumber"))
+		if err == nil && bn.ToInt().Sign() != 1 {
+			err = errors.New("wrong block number: 0x" + bn.ToInt().Text(16) + ", answer: smaller than 0")
 		}
 
 		elapsed := boomer.Now() - start
-		sendBoomerEvent("readBlockNumber", "Failed to call klay_blockNumber", elapsed, err, config.EndPoint)
+		sendBoomerEvent("readBlockNumber", "Failed to call blockNumber", elapsed, err, config.EndPoint)
 	}
 }
 
 // RunGetBlockByNumber creates a closure for get block by number test case
 func RunGetBlockByNumber(config *TCConfig) func() {
+	ns := readNamespace(config)
 	return func() {
 		ctx := context.Background()
 		cli := config.CliPool.Alloc().(*client.Client)
 		defer config.CliPool.Free(cli)
+		rpcCli := config.RpcCliPool.Alloc().(*rpc.Client)
+		defer config.RpcCliPool.Free(rpcCli)
 
 		ansBN := getRandomBlockNumber(cli, ctx)
 		start := boomer.Now()
 
-		block, err := cli.BlockByNumber(ctx, ansBN) //read the random block
-		if err == nil && block.Header().Number.Cmp(ansBN) != 0 {
-			err = errors.New("wrong block: 0x" + block.Header().Number.Text(16) + ", answer: 0x" + ansBN.Text(16))
+		var j json.RawMessage
+		err := rpcCli.CallContext(ctx, &j, readMethod(ns, "getBlockByNumber"), hexutil.EncodeBig(ansBN), true) //read the random block
+		if err == nil {
+			ret := gjson.Get(string(j), "number").String()
+			if ret != hexutil.EncodeBig(ansBN) {
+				err = errors.New("wrong block: " + ret + ", answer: " + hexutil.EncodeBig(ansBN))
+			}
 		}
 
 		elapsed := boomer.Now() - start
-		sendBoomerEvent("readGetBlockByNumber", "Failed to call klay_getBlockByNumber", elapsed, err, config.EndPoint)
+		sendBoomerEvent("readGetBlockByNumber", "Failed to call getBlockByNumber", elapsed, err, config.EndPoint)
 	}
 }
 
 // RunGetAccount creates a closure for get account test case
 func RunGetAccount(config *TCConfig) func() {
+	ns := readNamespace(config)
 	return func() {
 		ctx := context.Background()
 		rpcCli := config.RpcCliPool.Alloc().(*rpc.Client)
@@ -122,7 +137,7 @@ func RunGetAccount(config *TCConfig) func() {
 		start := boomer.Now()
 
 		var j json.RawMessage
-		err := rpcCli.CallContext(ctx, &j, "klay_getAccount", fromAccount.GetAddress(), "latest")
+		err := rpcCli.CallContext(ctx, &j, kaiaReadMethod(ns, "getAccount"), fromAccount.GetAddress(), "latest")
 		if err == nil {
 			ret := gjson.Get(string(j), "accType").String()
 			if ret != "1" {
@@ -131,12 +146,13 @@ func RunGetAccount(config *TCConfig) func() {
 		}
 
 		elapsed := boomer.Now() - start
-		sendBoomerEvent("readGetAccount", "Failed to call klay_getAccount", elapsed, err, config.EndPoint)
+		sendBoomerEvent("readGetAccount", "Failed to call getAccount", elapsed, err, config.EndPoint)
 	}
 }
 
 // RunGetBlockWithConsensusInfoByNumber creates a closure for get block with consensus info by number test case
 func RunGetBlockWithConsensusInfoByNumber(config *TCConfig) func() {
+	ns := readNamespace(config)
 	return func() {
 		ctx := context.Background()
 		cli := config.CliPool.Alloc().(*client.Client)
@@ -148,7 +164,7 @@ func RunGetBlockWithConsensusInfoByNumber(config *TCConfig) func() {
 		start := boomer.Now()
 
 		var j json.RawMessage
-		err := rpcCli.CallContext(ctx, &j, "klay_getBlockWithConsensusInfoByNumber", "0x"+ansBN.Text(16))
+		err := rpcCli.CallContext(ctx, &j, kaiaReadMethod(ns, "getBlockWithConsensusInfoByNumber"), "0x"+ansBN.Text(16))
 		if err == nil {
 			ret := gjson.Get(string(j), "number").String()
 			if !strings.Contains(ret, "0x"+ansBN.Text(16)) {
@@ -158,55 +174,58 @@ func RunGetBlockWithConsensusInfoByNumber(config *TCConfig) func() {
 
 		elapsed := boomer.Now() - start
 		sendBoomerEvent("readGetBlockWithConsensusInfoByNumber",
-			"Failed to call klay_GetBlockWithConsensusInfoByNumber", elapsed, err, config.EndPoint)
+			"Failed to call getBlockWithConsensusInfoByNumber", elapsed, err, config.EndPoint)
 	}
 }
 
 // RunGetStorageAt creates a closure for get storage at test case
 func RunGetStorageAt(config *TCConfig) func() {
+	ns := readNamespace(config)
 	return func() {
 		ctx := context.Background()
-		cli := config.CliPool.Alloc().(*client.Client)
-		defer config.CliPool.Free(cli)
+		rpcCli := config.RpcCliPool.Alloc().(*rpc.Client)
+		defer config.RpcCliPool.Free(rpcCli)
 
 		smartContractAccount := config.SmartContractAccounts[account.ContractReadApiCallContract]
 		contractAddr := smartContractAccount.GetAddress()
 		start := boomer.Now()
-		ret, err := cli.StorageAt(ctx, contractAddr, common.Hash{}, nil)
+		var ret hexutil.Bytes
+		err := rpcCli.CallContext(ctx, &ret, readMethod(ns, "getStorageAt"), contractAddr, common.Hash{}, "latest")
 		elapsed := boomer.Now() - start
 
 		if err == nil && new(big.Int).SetBytes(ret).Cmp(retValOfStorageAt) != 0 {
-			err = errors.New("wrong storage value: " + string(ret) + ", answer: " + retValOfStorageAt.String())
+			err = errors.New("wrong storage value: " + ret.String() + ", answer: " + retValOfStorageAt.String())
 		}
-		sendBoomerEvent("readGetStorageAt", "Failure to call klay_getStorageAt", elapsed, err, config.EndPoint)
+		sendBoomerEvent("readGetStorageAt", "Failure to call getStorageAt", elapsed, err, config.EndPoint)
 	}
 }
 
 // RunCall creates a closure for call test case
 func RunCall(config *TCConfig) func() {
+	ns := readNamespace(config)
 	return func() {
-		cli := config.CliPool.Alloc().(*client.Client)
-		defer config.CliPool.Free(cli)
+		rpcCli := config.RpcCliPool.Alloc().(*rpc.Client)
+		defer config.RpcCliPool.Free(rpcCli)
 
 		fromAccount := config.AccGrp.GetAccountRandomly().GetAddress()
 		contractAddr := config.SmartContractAccounts[account.ContractReadApiCallContract].GetAddress()
 		data := account.TestContractInfos[account.ContractReadApiCallContract].GenData(fromAccount, big.NewInt(0))
 
-		callMsg := kaia.CallMsg{
-			From: fromAccount,
-			To:   &contractAddr,
-			Data: data,
+		callArgs := map[string]interface{}{
+			"from": fromAccount,
+			"to":   contractAddr,
+			"data": hexutil.Bytes(data),
 		}
 
 		start := boomer.Now()
-		result, err := cli.CallContract(context.Background(), callMsg, nil)
+		var result hexutil.Bytes
+		err := rpcCli.CallContext(context.Background(), &result, readMethod(ns, "call"), callArgs, "latest")
 		elapsed := boomer.Now() - start
 
 		if err == nil {
 			// Parse the result using the same ABI
-			abiStr := `[{"inputs":[],"name":"get","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`
-			parsedABI, err := abi.JSON(strings.NewReader(abiStr))
-			if err == nil {
+			var parsedABI abi.ABI
+			if parsedABI, err = abi.JSON(strings.NewReader(getABI)); err == nil {
 				var ret *big.Int
 				err = parsedABI.UnpackIntoInterface(&ret, "get", result)
 				if err == nil && ret.Cmp(retValOfCall) != 0 {
@@ -214,36 +233,38 @@ func RunCall(config *TCConfig) func() {
 				}
 			}
 		}
-		sendBoomerEvent("readCall", "Failed to call klay_call", elapsed, err, config.EndPoint)
+		sendBoomerEvent("readCall", "Failed to call call", elapsed, err, config.EndPoint)
 	}
 }
 
 // RunEstimateGas creates a closure for estimate gas test case
 func RunEstimateGas(config *TCConfig) func() {
+	ns := readNamespace(config)
 	return func() {
 		ctx := context.Background()
-		cli := config.CliPool.Alloc().(*client.Client)
-		defer config.CliPool.Free(cli)
+		rpcCli := config.RpcCliPool.Alloc().(*rpc.Client)
+		defer config.RpcCliPool.Free(rpcCli)
 
 		fromAccount := config.AccGrp.GetAccountRandomly().GetAddress()
 		contractAddr := config.SmartContractAccounts[account.ContractReadApiCallContract].GetAddress()
 		data := account.TestContractInfos[account.ContractReadApiCallContract].GenData(fromAccount, big.NewInt(1))
 
-		callMsg := kaia.CallMsg{
-			From:     fromAccount,
-			To:       &contractAddr,
-			Gas:      1100000,
-			GasPrice: big.NewInt(75000000000), // Default gas price: 25 Gwei
-			Value:    big.NewInt(0),
-			Data:     data,
+		callArgs := map[string]interface{}{
+			"from":     fromAccount,
+			"to":       contractAddr,
+			"gas":      hexutil.Uint64(1100000),
+			"gasPrice": (*hexutil.Big)(big.NewInt(75000000000)), // Default gas price: 25 Gwei
+			"value":    (*hexutil.Big)(big.NewInt(0)),
+			"data":     hexutil.Bytes(data),
 		}
 		start := boomer.Now()
-		ret, err := cli.EstimateGas(ctx, callMsg)
+		var ret hexutil.Uint64
+		err := rpcCli.CallContext(ctx, &ret, readMethod(ns, "estimateGas"), callArgs)
 		elapsed := boomer.Now() - start
 
 		if err == nil && ret == 0 {
 			err = errors.New("wrong estimate gas: " + strconv.Itoa(int(ret)))
 		}
-		sendBoomerEvent("readEstimateGas", "Failed to call klay_estimateGas", elapsed, err, config.EndPoint)
+		sendBoomerEvent("readEstimateGas", "Failed to call estimateGas", elapsed, err, config.EndPoint)
 	}
 }
@@ -1,60 +1,200 @@
 package testcase
 
 import (
+	"bufio"
 	"context"
 	"errors"
+	"fmt"
 	"log"
 	"math/big"
 	"math/rand"
 	"os"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	kaia "github.com/kaiachain/kaia"
 	"github.com/kaiachain/kaia-load-tester/klayslave/account"
 	"github.com/kaiachain/kaia/blockchain/types"
 	"github.com/kaiachain/kaia/client"
 	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/networks/rpc"
 	"github.com/myzhan/boomer"
 )
 
-// Receipt check related variables
-var (
-	hashPoolSize      = 100 * 5 * 60 // for init 5min, if input send tps is 100Txs/Sec
-	defaultInitSendTx = 1000 * 10    // for init 10sec, if input send TPS is 1000Txs/Sec
+// Hash sources of the receipt check TC
+const (
+	HashSourceOwn    = "own"    // hashes of the txs sent by the TC itself
+	HashSourceBlocks = "blocks" // hashes found by scanning the recent blocks and following new ones
+	HashSourceFile   = "file"   // historical hashes read from a file, one hex hash per line
+)
 
-	tail     = 0
-	isFull   = false
-	hashPool = make([]common.Hash, hashPoolSize)
-	rwMutex  sync.RWMutex
+// ReceiptCheckConfig configures receiptCheckTx.
+type ReceiptCheckConfig struct {
+	ReadPerSend   int     // read:send = ReadPerSend:1
+	PoolSize      int     // number of hashes kept for the reads
+	WarmUp        int     // number of txs sent before the reads start, only with HashSourceOwn
+	HashSource    string  // HashSourceOwn, HashSourceBlocks or HashSourceFile
+	HashFile      string  // file of the hashes for HashSourceFile
+	MissingRatio  float64 // share of the reads which query a non-existent hash
+	TxByHashRatio float64 // share of the reads which call getTransactionByHash instead of getTransactionReceipt
+	MaxScanBlocks int     // max number of recent blocks scanned backward to fill the pool with HashSourceBlocks
+}
 
-	ratioReadPerSend = 9 // read:send = ratioReadPerSend:1
+// newReceiptCheckConfig reads the configuration of receiptCheckTx from its parameters.
+func newReceiptCheckConfig(config *TCConfig) ReceiptCheckConfig {
+	rc := ReceiptCheckConfig{
+		ReadPerSend:   config.IntParam("readPerSend"),
+		PoolSize:      config.IntParam("poolSize"),
+		WarmUp:        config.IntParam("warmUp"),
+		HashSource:    config.StringParam("hashSource"),
+		HashFile:      config.StringParam("hashFile"),
+		MissingRatio:  config.Float64Param("missingRatio"),
+		TxByHashRatio: config.Float64Param("txByHashRatio"),
+		MaxScanBlocks: config.IntParam("scanBlocks"),
+	}
+	if rc.ReadPerSend < 0 || rc.PoolSize <= 0 || rc.WarmUp < 0 || rc.MaxScanBlocks < 0 {
+		log.Fatalf("%v.readPerSend, %v.warmUp and %v.scanBlocks should not be negative, and %v.poolSize should be positive", config.Name, config.Name, config.Name, config.Name)
+	} else if rc.MissingRatio < 0 || rc.MissingRatio > 1 || rc.TxByHashRatio < 0 || rc.TxByHashRatio > 1 {
+		log.Fatalf("%v.missingRatio and %v.txByHashRatio should be between 0 and 1", config.Name, config.Name)
+	} else if rc.HashSource != HashSourceOwn && rc.HashSource != HashSourceBlocks && rc.HashSource != HashSourceFile {
+		log.Fatalf("%v.hashSource should be one of %v, %v and %v: %v", config.Name, HashSourceOwn, HashSourceBlocks, HashSourceFile, rc.HashSource)
+	} else if rc.HashSource == HashSourceFile && rc.HashFile == "" {
+		log.Fatalf("%v.hashFile is required for the hash source %v", config.Name, HashSourceFile)
+	}
+	return rc
+}
 
-	cnt      uint32
-	initFlag = false
-)
+// hashPool is a ring buffer of tx hashes which the reads pick from randomly.
+type hashPool struct {
+	mu     sync.RWMutex
+	hashes []common.Hash
+	tail   int
+	isFull bool
+}
 
-// addHash adds a hash to the hash pool
-func addHash(hash common.Hash) {
-	rwMutex.Lock()
-	hashPool[tail] = hash
+func newHashPool(size int) *hashPool {
+	return &hashPool{hashes: make([]common.Hash, size)}
+}
 
-	tail = (tail + 1) % hashPoolSize
-	if tail == 0 {
-		isFull = true
+// add adds a hash to the pool, overwriting the oldest one if the pool is full.
+func (p *hashPool) add(hash common.Hash) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.hashes[p.tail] = hash
+	p.tail = (p.tail + 1) % len(p.hashes)
+	if p.tail == 0 {
+		p.isFull = true
 	}
+}
 
-	rwMutex.Unlock()
+// len returns the number of hashes in the pool.
+func (p *hashPool) len() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.isFull {
+		return len(p.hashes)
+	}
+	return p.tail
 }
 
-// getHash gets a random hash from the hash pool
-func getHash() common.Hash {
-	rwMutex.RLock()
-	defer rwMutex.RUnlock()
-	if isFull {
-		return hashPool[rand.Int()%hashPoolSize]
+// random returns a random hash from the pool. The pool should not be empty.
+func (p *hashPool) random() common.Hash {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.isFull {
+		return p.hashes[rand.Int()%len(p.hashes)]
+	}
+	return p.hashes[rand.Int()%p.tail]
+}
+
+// loadHashFile reads the hashes from a file, one hex hash per line. Empty lines and lines starting with # are skipped.
+func loadHashFile(path string) ([]common.Hash, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var hashes []common.Hash
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		b, err := hexutil.Decode(line)
+		if err != nil || len(b) != common.HashLength {
+			return nil, fmt.Errorf("invalid tx hash %q", line)
+		}
+		hashes = append(hashes, common.BytesToHash(b))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(hashes) == 0 {
+		return nil, fmt.Errorf("no tx hash in %v", path)
+	}
+	return hashes, nil
+}
+
+// scanBlocks fills the pool with the tx hashes of the recent blocks, then keeps adding the hashes of new blocks.
+func scanBlocks(cli *rpc.Client, pool *hashPool, maxScanBlocks int) {
+	var block struct {
+		Transactions []common.Hash `json:"transactions"`
+	}
+	getBlock := func(number uint64) error {
+		block.Transactions = nil
+		return cli.CallContext(context.Background(), &block, "kaia_getBlockByNumber", hexutil.Uint64(number), false)
+	}
+
+	var head hexutil.Uint64
+	for {
+		err := cli.CallContext(context.Background(), &head, "kaia_blockNumber")
+		if err == nil {
+			break
+		}
+		log.Printf("receiptCheckTx: failed to get the block number: %v", err)
+		time.Sleep(time.Second)
+	}
+
+	// Scan backward until the pool is full
+	for n, scanned := uint64(head), 0; n > 0 && scanned < maxScanBlocks && pool.len() < len(pool.hashes); n, scanned = n-1, scanned+1 {
+		if err := getBlock(n); err != nil {
+			log.Printf("receiptCheckTx: failed to get block %d: %v", n, err)
+			continue
+		}
+		for _, hash := range block.Transactions {
+			pool.add(hash)
+		}
 	}
-	return hashPool[rand.Int()%tail]
+	log.Printf("receiptCheckTx: %d tx hash(es) collected from the blocks before #%d", pool.len(), head)
+
+	// Follow the new blocks
+	next := uint64(head) + 1
+	for range time.Tick(time.Second) {
+		var latest hexutil.Uint64
+		if err := cli.CallContext(context.Background(), &latest, "kaia_blockNumber"); err != nil {
+			continue
+		}
+		for ; next <= uint64(latest); next++ {
+			if err := getBlock(next); err != nil {
+				break
+			}
+			for _, hash := range block.Transactions {
+				pool.add(hash)
+			}
+		}
+	}
+}
+
+// randomHash returns a random hash, which is not likely to exist on the chain.
+func randomHash() common.Hash {
+	var hash common.Hash
+	rand.Read(hash[:])
+	return hash
 }
 
 // doubleLock locks two accounts in a consistent order to prevent deadlock
@@ -84,7 +224,7 @@ func doubleUnlock(to *account.Account, from *account.Account) {
 }
 
 // runReceiptCheckSendTx creates a closure for receipt check send transaction
-func runReceiptCheckSendTx(config *TCConfig) func() {
+func runReceiptCheckSendTx(config *TCConfig, rcConfig ReceiptCheckConfig, pool *hashPool) func() {
 	return func() {
 		cli := config.CliPool.Alloc().(*client.Client)
 		defer config.CliPool.Free(cli)
@@ -95,7 +235,9 @@ func runReceiptCheckSendTx(config *TCConfig) func() {
 
 		start := boomer.Now()
 		hash, _, err := from.TransferSignedTx(cli, to, value)
-		addHash(hash)
+		if err == nil && rcConfig.HashSource == HashSourceOwn {
+			pool.add(hash)
+		}
 		elapsed := boomer.Now() - start
 
 		if err == nil {
@@ -106,22 +248,46 @@ func runReceiptCheckSendTx(config *TCConfig) func() {
 	}
 }
 
-// runReceiptCheckReadTx creates a closure for receipt check read transaction
-func runReceiptCheckReadTx(config *TCConfig) func() {
+// runReceiptCheckReadTx creates a closure for receipt check read transaction.
+// A share of the reads query a non-existent hash, for which kaia.NotFound is the expected result.
+func runReceiptCheckReadTx(config *TCConfig, rcConfig ReceiptCheckConfig, pool *hashPool) func() {
 	return func() {
 		cli := config.CliPool.Alloc().(*client.Client)
 		defer config.CliPool.Free(cli)
 
 		ctx := context.Background()
-		hash := getHash()
+		name := "read tx"
+		missing := rand.Float64() < rcConfig.MissingRatio
+		hash := pool.random()
+		if missing {
+			hash = randomHash()
+			name += " (missing)"
+		}
+		byHash := rand.Float64() < rcConfig.TxByHashRatio
+		if byHash {
+			name = strings.Replace(name, "read tx", "read tx by hash", 1)
+		}
 
 		start := boomer.Now()
 
-		receipt, err := cli.TransactionReceipt(ctx, hash)
+		var err error
+		var result interface{}
+		if byHash {
+			result, _, err = cli.TransactionByHash(ctx, hash)
+		} else {
+			result, err = cli.TransactionReceipt(ctx, hash)
+		}
+		if missing {
+			if err == kaia.NotFound {
+				err = nil
+			} else if err == nil {
+				err = fmt.Errorf("non-existent hash %v is found", hash.String())
+			}
+		}
 		if err == nil {
-			if rand.Int()%(1000*60) == 0 {
+			if !missing && rand.Int()%(1000*60) == 0 {
 				log.Printf("pid(%v) : hash(%v) receipt checked\n", os.Getpid(), hash.String())
-				log.Printf("%v", receipt)
+				log.Printf("%v", result)
 			}
 		} else {
 			log.Printf("pid(%v) : hash(%v) receipt check err : %v\n", os.Getpid(), hash.String(), err)
@@ -130,31 +296,61 @@ func runReceiptCheckReadTx(config *TCConfig) func() {
 		elapsed := boomer.Now() - start
 
 		if err == nil {
-			boomer.Events.Publish("request_success", "receiptCheckTx", "read tx"+" to "+config.EndPoint, elapsed, int64(10))
+			boomer.Events.Publish("request_success", "receiptCheckTx", name+" to "+config.EndPoint, elapsed, int64(10))
 		} else {
-			boomer.Events.Publish("request_failure", "receiptCheckTx", "read tx"+" to "+config.EndPoint, elapsed, err.Error())
+			boomer.Events.Publish("request_failure", "receiptCheckTx", name+" to "+config.EndPoint, elapsed, err.Error())
 		}
 	}
 }
 
-// RunReceiptCheckTC creates a closure for receipt check test case
+// RunReceiptCheckTC creates a closure for receipt check test case.
+// With HashSourceOwn, it only sends txs until the warm-up is done. Then it sends and reads at the configured ratio.
 func RunReceiptCheckTC(config *TCConfig) func() {
+	rcConfig := newReceiptCheckConfig(config)
+	pool := newHashPool(rcConfig.PoolSize)
+
+	switch rcConfig.HashSource {
+	case HashSourceFile:
+		hashes, err := loadHashFile(rcConfig.HashFile)
+		if err != nil {
+			log.Fatalf("receiptCheckTx: failed to load the hash file: %v", err)
+		}
+		pool = newHashPool(len(hashes))
+		for _, hash := range hashes {
+			pool.add(hash)
+		}
+		log.Printf("receiptCheckTx: %d tx hash(es) loaded from %v", len(hashes), rcConfig.HashFile)
+	case HashSourceBlocks:
+		rpcCli, err := rpc.Dial(config.EndPoint)
+		if err != nil {
+			log.Fatalf("Failed to connect RPC: %v", err)
+		}
+		go scanBlocks(rpcCli, pool, rcConfig.MaxScanBlocks)
+	}
+
+	sendTx := runReceiptCheckSendTx(config, rcConfig, pool)
+	readTx := runReceiptCheckReadTx(config, rcConfig, pool)
+
+	var cnt uint32
+	var warmedUp int32
 	return func() {
 		nc := atomic.AddUint32(&cnt, 1)
 
-		if !initFlag && nc < uint32(defaultInitSendTx) {
-			runReceiptCheckSendTx(config)()
-		} else {
-			initFlag = true
+		if atomic.LoadInt32(&warmedUp) == 0 {
+			if (rcConfig.HashSource == HashSourceOwn && nc < uint32(rcConfig.WarmUp)) || pool.len() == 0 {
+				sendTx()
+				return
+			}
+			atomic.StoreInt32(&warmedUp, 1)
+		}
 
-			// following logic can control the ratio between send/read task
-			nc = nc % uint32(ratioReadPerSend+1)
+		// following logic can control the ratio between send/read task
+		nc = nc % uint32(rcConfig.ReadPerSend+1)
 
-			if nc == uint32(ratioReadPerSend) {
-				runReceiptCheckSendTx(config)()
-			} else {
-				runReceiptCheckReadTx(config)()
-			}
+		if nc == uint32(rcConfig.ReadPerSend) {
+			sendTx()
+		} else {
+			readTx()
 		}
 	}
 }
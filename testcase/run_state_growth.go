@@ -0,0 +1,51 @@
+package testcase
+
+import (
+	"log"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/kaiachain/kaia-load-tester/klayslave/account"
+	"github.com/kaiachain/kaia/client"
+)
+
+// The state entries added by the sent txs of the state growth TCs, which reportStateGrowth logs.
+// They are estimated, as some txs may not be mined.
+var (
+	stateGrowthAccounts uint64
+	stateGrowthSlots    uint64
+)
+
+// RunStateGrowthAccountTC creates a closure for state growth account test case.
+// Every tx sends 1 kei to a brand-new address, which adds an account to the state trie.
+func RunStateGrowthAccountTC(config *TCConfig) func() {
+	stateGrowthReportOnce.Do(func() { go reportStateGrowth() })
+	txFunc := func(cli *client.Client, from *account.Account, to *account.Account, value *big.Int) (interface{}, *big.Int, error) {
+		// a zero value would leave an empty account, which the node deletes
+		hash, gasPrice, err := from.TransferNewValueTransferTx(cli, account.NewKaiaAccount(0), big.NewInt(1))
+		if err == nil {
+			atomic.AddUint64(&stateGrowthAccounts, 1)
+		}
+		return hash, gasPrice, err
+	}
+	return RunBaseValueTransfer(config, txFunc)
+}
+
+// RunStateGrowthStorageTC creates a closure for state growth storage test case.
+// Every tx writes the slots parameter new storage slots from a random base in the state growth contract.
+func RunStateGrowthStorageTC(config *TCConfig) func() {
+	slots := config.IntParam("slots")
+	if slots <= 0 || slots > 200 {
+		// every new slot costs 22100 gas, which should fit in the gas limit of 5000000
+		log.Fatalf("%v.slots should be between 1 and 200: %v", config.Name, slots)
+	}
+	stateGrowthReportOnce.Do(func() { go reportStateGrowth() })
+	txFunc := func(cli *client.Client, from *account.Account, to *account.Account) (interface{}, *big.Int, error) {
+		tx, gasPrice, err := from.TransferNewSmartContractExecutionTx(cli, to, nil, account.GenStateGrowthData(slots))
+		if err == nil {
+			atomic.AddUint64(&stateGrowthSlots, uint64(slots))
+		}
+		return tx, gasPrice, err
+	}
+	return RunBaseWithContract(config, txFunc)
+}
@@ -0,0 +1,95 @@
+package testcase
+
+import (
+	"log"
+	"math/big"
+	"math/rand"
+
+	"github.com/kaiachain/kaia-load-tester/klayslave/account"
+	"github.com/kaiachain/kaia/client"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/params"
+	"github.com/myzhan/boomer"
+)
+
+// DeployConfig configures the contracts deployed by uniqueDeployTC and create2DeployTC.
+type DeployConfig struct {
+	Sizes     []int // runtime code sizes, one of them is picked for every deploy
+	CallAfter bool  // call the new contract by the next tx of the sender
+}
+
+// deployParams are the parameters of uniqueDeployTC and create2DeployTC.
+var deployParams = []TCParam{
+	{Name: "sizes", Default: "100,1000,10000,24576", Usage: "runtime code sizes, one of them is picked for every deploy, separated by comma"},
+	{Name: "callAfter", Default: false, Usage: "call every deployed contract right after the deploy"},
+}
+
+// newDeployConfig reads the configuration of uniqueDeployTC or create2DeployTC from its parameters.
+func newDeployConfig(config *TCConfig) DeployConfig {
+	dc := DeployConfig{Sizes: config.IntListParam("sizes"), CallAfter: config.BoolParam("callAfter")}
+	if len(dc.Sizes) == 0 {
+		log.Fatalf("%v.sizes should not be empty", config.Name)
+	}
+	for _, size := range dc.Sizes {
+		if size < account.MinUniqueContractSize || size > params.MaxCodeSize {
+			log.Fatalf("%v.sizes should be between %v and %v: %v", config.Name, account.MinUniqueContractSize, params.MaxCodeSize, size)
+		}
+	}
+	return dc
+}
+
+// uniqueContractCode returns the initcode of a unique contract of a size picked from DeployConfig.Sizes.
+func uniqueContractCode(dc DeployConfig) []byte {
+	return account.GenUniqueContractCode(dc.Sizes[rand.Intn(len(dc.Sizes))])
+}
+
+// callNewContract calls the contract deployed by the sender if DeployConfig.CallAfter is set.
+// The call follows the deploy in the nonce order of the sender, and is reported as "<tc> call".
+func callNewContract(config *TCConfig, dc DeployConfig, cli *client.Client, from *account.Account, addr common.Address) {
+	if !dc.CallAfter {
+		return
+	}
+	name := config.Name + " call to " + config.EndPoint
+
+	start := boomer.Now()
+	// an ethereum tx, as a kaia tx to the contract is rejected until the deploy is mined
+	_, _, err := from.TransferNewLegacyTxWithEth(cli, account.NewKaiaAccountWithAddr(0, addr), big.NewInt(0), nil)
+	elapsed := boomer.Now() - start
+	if err != nil {
+		boomer.Events.Publish("request_failure", "http", name, elapsed, err.Error())
+		return
+	}
+	boomer.Events.Publish("request_success", "http", name, elapsed, int64(10))
+}
+
+// RunUniqueDeployTC creates a closure for unique contract deploy test case.
+// Every tx deploys a contract whose code differs from all others, so that the code store of the node does not deduplicate it.
+func RunUniqueDeployTC(config *TCConfig) func() {
+	dc := newDeployConfig(config)
+	txFunc := func(cli *client.Client, from *account.Account, to *account.Account, value *big.Int) (interface{}, *big.Int, error) {
+		addr, tx, gasPrice, err := from.TransferNewSmartContractDeployTx(cli, to, big.NewInt(0), uniqueContractCode(dc), false)
+		if err != nil {
+			return nil, nil, err
+		}
+		callNewContract(config, dc, cli, from, addr)
+		return deployedTx{tx: tx, contractAddress: addr}, gasPrice, nil
+	}
+	return RunBaseValueTransfer(config, txFunc)
+}
+
+// RunCreate2DeployTC creates a closure for CREATE2 deploy test case.
+// Every tx deploys a unique contract by the CREATE2 factory with a random salt.
+func RunCreate2DeployTC(config *TCConfig) func() {
+	dc := newDeployConfig(config)
+	txFunc := func(cli *client.Client, from *account.Account, to *account.Account) (interface{}, *big.Int, error) {
+		var salt common.Hash
+		rand.Read(salt[:])
+		addr, tx, gasPrice, err := from.TransferNewCreate2DeployTx(cli, to, salt, uniqueContractCode(dc))
+		if err != nil {
+			return nil, nil, err
+		}
+		callNewContract(config, dc, cli, from, addr)
+		return tx, gasPrice, nil
+	}
+	return RunBaseWithContract(config, txFunc)
+}
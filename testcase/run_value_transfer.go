@@ -14,19 +14,21 @@ type ValueTransferTxFunc = func(*client.Client, *account.Account, *account.Accou
 
 // RunBaseValueTransfer creates a closure that executes a test case with common logic
 func RunBaseValueTransfer(config *TCConfig, txFunc ValueTransferTxFunc) func() {
+	maxValue := config.maxValue()
 	return func() {
 		cli := config.CliPool.Alloc().(*client.Client)
 		defer config.CliPool.Free(cli)
 
 		from := config.AccGrp.GetAccountRandomly()
 		to := config.AccGrp.GetAccountRandomly()
-		value := big.NewInt(int64(rand.Int() % 3))
+		value := big.NewInt(int64(rand.Intn(maxValue + 1)))
 
 		start := boomer.Now()
-		_, _, err := txFunc(cli, from, to, value)
+		sent, _, err := txFunc(cli, from, to, value)
 		elapsed := boomer.Now() - start
 
 		if err == nil {
+			onTxSent(config.Name, sent)
 			boomer.Events.Publish("request_success", "http", config.Name+" to "+config.EndPoint, elapsed, int64(10))
 		} else {
 			boomer.Events.Publish("request_failure", "http", config.Name+" to "+config.EndPoint, elapsed, err.Error())
@@ -50,8 +52,9 @@ func RunNewFeeDelegatedValueTransferTC(config *TCConfig) func() {
 }
 
 func RunNewFeeDelegatedValueTransferWithRatioTC(config *TCConfig) func() {
+	feeRatio := config.feeRatio()
 	txFunc := func(cli *client.Client, from *account.Account, to *account.Account, value *big.Int) (interface{}, *big.Int, error) {
-		return from.TransferNewFeeDelegatedValueTransferWithRatioTx(cli, to, value)
+		return from.TransferNewFeeDelegatedValueTransferWithRatioTx(cli, to, value, feeRatio)
 	}
 	return RunBaseValueTransfer(config, txFunc)
 }
@@ -78,8 +81,9 @@ func RunNewFeeDelegatedCancelTC(config *TCConfig) func() {
 }
 
 func RunNewFeeDelegatedCancelWithRatioTC(config *TCConfig) func() {
+	feeRatio := config.feeRatio()
 	txFunc := func(cli *client.Client, from *account.Account, to *account.Account, value *big.Int) (interface{}, *big.Int, error) {
-		return from.TransferNewFeeDelegatedCancelWithRatioTx(cli, to, value)
+		return from.TransferNewFeeDelegatedCancelWithRatioTx(cli, to, value, feeRatio)
 	}
 	return RunBaseValueTransfer(config, txFunc)
 }
@@ -87,8 +91,11 @@ func RunNewFeeDelegatedCancelWithRatioTC(config *TCConfig) func() {
 func RunNewSmartContractDeployTC(config *TCConfig) func() {
 	txFunc := func(cli *client.Client, from *account.Account, to *account.Account, value *big.Int) (interface{}, *big.Int, error) {
 		newTo := account.NewKaiaAccount(0)
-		_, _, _, err := from.TransferNewSmartContractDeployTx(cli, newTo, value, account.TestContractInfos[account.ContractGeneral].Bytecode, false)
-		return nil, nil, err
+		addr, tx, gasPrice, err := from.TransferNewSmartContractDeployTx(cli, newTo, value, account.TestContractInfos[account.ContractGeneral].Bytecode, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		return deployedTx{tx: tx, contractAddress: addr}, gasPrice, nil
 	}
 	return RunBaseValueTransfer(config, txFunc)
 }
@@ -101,8 +108,9 @@ func RunNewFeeDelegatedSmartContractDeployTC(config *TCConfig) func() {
 }
 
 func RunNewFeeDelegatedSmartContractDeployWithRatioTC(config *TCConfig) func() {
+	feeRatio := config.feeRatio()
 	txFunc := func(cli *client.Client, from *account.Account, to *account.Account, value *big.Int) (interface{}, *big.Int, error) {
-		return from.TransferNewFeeDelegatedSmartContractDeployWithRatioTx(cli, to, value)
+		return from.TransferNewFeeDelegatedSmartContractDeployWithRatioTx(cli, to, value, feeRatio)
 	}
 	return RunBaseValueTransfer(config, txFunc)
 }
@@ -122,8 +130,9 @@ func RunNewFeeDelegatedValueTransferMemoTC(config *TCConfig) func() {
 }
 
 func RunNewFeeDelegatedValueTransferMemoWithRatioTC(config *TCConfig) func() {
+	feeRatio := config.feeRatio()
 	txFunc := func(cli *client.Client, from *account.Account, to *account.Account, value *big.Int) (interface{}, *big.Int, error) {
-		return from.TransferNewFeeDelegatedValueTransferMemoWithRatioTx(cli, to, value)
+		return from.TransferNewFeeDelegatedValueTransferMemoWithRatioTx(cli, to, value, feeRatio)
 	}
 	return RunBaseValueTransfer(config, txFunc)
 }
@@ -157,8 +166,9 @@ func RunNewFeeDelegatedAccountUpdateTC(config *TCConfig) func() {
 }
 
 func RunNewFeeDelegatedAccountUpdateWithRatioTC(config *TCConfig) func() {
+	feeRatio := config.feeRatio()
 	txFunc := func(cli *client.Client, from *account.Account, to *account.Account, value *big.Int) (interface{}, *big.Int, error) {
-		return from.TransferNewFeeDelegatedAccountUpdateWithRatioTx(cli, to, value)
+		return from.TransferNewFeeDelegatedAccountUpdateWithRatioTx(cli, to, value, feeRatio)
 	}
 	return RunBaseValueTransfer(config, txFunc)
 }
@@ -36,6 +36,7 @@ func Init(accGrp *account.AccGroup, endpoint string, testContracts []account.Tes
 		if err != nil {
 			log.Fatalf("Failed to connect RPC: %v", err)
 		}
+		config.setGasLimit(c)
 		return c
 	}
 
@@ -59,6 +60,14 @@ func Init(accGrp *account.AccGroup, endpoint string, testContracts []account.Tes
 		accs = accGrp.GetAccListByName(account.AccListForGaslessRevertTx)
 	} else if tcName == "gaslessOnlyApproveTC" {
 		accs = accGrp.GetAccListByName(account.AccListForGaslessApproveTx)
+	} else if tcName == "publicKeyValueTransferTC" || tcName == "publicKeySmartContractExecutionTC" {
+		accs = accGrp.GetAccListByName(account.AccListForPublicKeyTx)
+	} else if tcName == "multisigValueTransferTC" || tcName == "multisigSmartContractExecutionTC" {
+		accs = accGrp.GetAccListByName(account.AccListForMultiSigTx)
+	} else if tcName == "roleBasedValueTransferTC" || tcName == "roleBasedSmartContractExecutionTC" {
+		accs = accGrp.GetAccListByName(account.AccListForRoleBasedTx)
+	} else if tcName == "newAccountCreationTC" {
+		accs = accGrp.GetAccListByName(account.AccListForNewAccounts)
 	}
 
 	config.AccGrp = account.NewAccountSet(accs)
@@ -70,7 +79,7 @@ func Init(accGrp *account.AccGroup, endpoint string, testContracts []account.Tes
 	}
 
 	// Set TargetTxTypeList for auction test cases
-	if tcName == "auctionBidTC" || tcName == "auctionRevertedBidTC" {
+	if tcName == "auctionBidTC" || tcName == "auctionRevertedBidTC" || tcName == "auctionCompetitiveBidTC" {
 		config.AuctionTargetTxTypeList = auctionTargetTxTypeList
 	}
 
@@ -82,6 +91,7 @@ func Init(accGrp *account.AccGroup, endpoint string, testContracts []account.Tes
 			if err != nil {
 				log.Fatalf("Failed to connect RPC: %v", err)
 			}
+			config.setGasLimit(c)
 			return c
 		}
 
@@ -90,3 +100,11 @@ func Init(accGrp *account.AccGroup, endpoint string, testContracts []account.Tes
 
 	return config
 }
+
+// onTxSent is called with what a tx func returned after the tx is sent successfully.
+func onTxSent(tcName string, sent interface{}) {
+	verifySampled(tcName, sent)
+	recordRecentTx(sent)
+	hash, _, _ := describeSentTx(sent)
+	recordLogTx(hash)
+}
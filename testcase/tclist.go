@@ -11,6 +11,7 @@ const (
 	NewSmartContractExecutionTCName                      = "newSmartContractExecutionTC"
 	Erc20TransferTCName                                  = "erc20TransferTC"
 	CpuHeavyTCName                                       = "cpuHeavyTC"
+	ComputationLimitTCName                               = "computationLimitTC"
 	NewFeeDelegatedValueTransferTCName                   = "newFeeDelegatedValueTransferTC"
 	NewFeeDelegatedValueTransferWithRatioTCName          = "newFeeDelegatedValueTransferWithRatioTC"
 	NewFeeDelegatedValueTransferMemoTCName               = "newFeeDelegatedValueTransferMemoTC"
@@ -25,11 +26,18 @@ const (
 	NewCancelTCName                                      = "newCancelTC"
 	NewFeeDelegatedCancelTCName                          = "newFeeDelegatedCancelTC"
 	NewFeeDelegatedCancelWithRatioTCName                 = "newFeeDelegatedCancelWithRatioTC"
+	NewChainDataAnchoringTCName                          = "newChainDataAnchoringTC"
+	NewFeeDelegatedChainDataAnchoringTCName              = "newFeeDelegatedChainDataAnchoringTC"
+	NewFeeDelegatedChainDataAnchoringWithRatioTCName     = "newFeeDelegatedChainDataAnchoringWithRatioTC"
 	NewSmartContractDeployTCName                         = "newSmartContractDeployTC"
+	UniqueDeployTCName                                   = "uniqueDeployTC"
+	Create2DeployTCName                                  = "create2DeployTC"
 	LargeMemoTCName                                      = "largeMemoTC"
+	LogHeavyTCName                                       = "logHeavyTC"
 	Erc721TransferTCName                                 = "erc721TransferTC"
 	AuctionBidTCName                                     = "auctionBidTC"
 	AuctionRevertedBidTCName                             = "auctionRevertedBidTC"
+	AuctionCompetitiveBidTCName                          = "auctionCompetitiveBidTC"
 	GaslessTransactionTCName                             = "gaslessTransactionTC"
 	GaslessRevertTransactionTCName                       = "gaslessRevertTransactionTC"
 	GaslessOnlyApproveTCName                             = "gaslessOnlyApproveTC"
@@ -41,9 +49,22 @@ const (
 	ReadGetStorageAtTCName                               = "readGetStorageAt"
 	ReadCallTCName                                       = "readCall"
 	ReadEstimateGasTCName                                = "readEstimateGas"
+	ReadHistoricalBalanceTCName                          = "readHistoricalBalance"
+	ReadHistoricalNonceTCName                            = "readHistoricalNonce"
+	ReadHistoricalCodeTCName                             = "readHistoricalCode"
+	ReadHistoricalStorageAtTCName                        = "readHistoricalStorageAt"
+	ReadHistoricalCallTCName                             = "readHistoricalCall"
+	ReadNamespaceDiffTCName                              = "readNamespaceDiff"
+	DebugTraceTransactionTCName                          = "debugTraceTransactionTC"
+	DebugTraceBlockByNumberTCName                        = "debugTraceBlockByNumberTC"
+	DebugTraceCallTCName                                 = "debugTraceCallTC"
+	GetLogsTCName                                        = "getLogsTC"
+	FilterChangesTCName                                  = "filterChangesTC"
 	InternalTxTCName                                     = "internalTxTC"
 	MintNFTTCName                                        = "mintNFTTC"
 	StorageTrieWriteTCName                               = "storageTrieWriteTC"
+	StateGrowthAccountTCName                             = "stateGrowthAccountTC"
+	StateGrowthStorageTCName                             = "stateGrowthStorageTC"
 	UserStorageSetTCName                                 = "userStorageSetTC"
 	UserStorageSetGetTCName                              = "userStorageSetGetTC"
 	NewAccountUpdateTCName                               = "newAccountUpdateTC"
@@ -58,15 +79,43 @@ const (
 	EthereumTxDynamicFeeTCName                           = "ethereumTxDynamicFeeTC"
 	NewEthereumAccessListTCName                          = "newEthereumAccessListTC"
 	NewEthereumDynamicFeeTCName                          = "newEthereumDynamicFeeTC"
+	EIP7702SetCodeTCName                                 = "eip7702SetCodeTC"
+	EIP7702MultiAuthTCName                               = "eip7702MultiAuthTC"
+	EIP7702RedelegateTCName                              = "eip7702RedelegateTC"
+	EIP7702RevokeTCName                                  = "eip7702RevokeTC"
+	EIP7702DelegatedCallTCName                           = "eip7702DelegatedCallTC"
+	PublicKeyValueTransferTCName                         = "publicKeyValueTransferTC"
+	PublicKeySmartContractExecutionTCName                = "publicKeySmartContractExecutionTC"
+	MultiSigValueTransferTCName                          = "multisigValueTransferTC"
+	MultiSigSmartContractExecutionTCName                 = "multisigSmartContractExecutionTC"
+	RoleBasedValueTransferTCName                         = "roleBasedValueTransferTC"
+	RoleBasedSmartContractExecutionTCName                = "roleBasedSmartContractExecutionTC"
+	NewAccountCreationTCName                             = "newAccountCreationTC"
+	CreatedAccountValueTransferTCName                    = "createdAccountValueTransferTC"
+	InvalidChainIDTCName                                 = "invalidChainIDTC"
+	InvalidSignatureTCName                               = "invalidSignatureTC"
+	InvalidFeePayerSignatureTCName                       = "invalidFeePayerSignatureTC"
+	UnderpricedTxTCName                                  = "underpricedTxTC"
+	OversizedTxTCName                                    = "oversizedTxTC"
+	OverGasLimitTxTCName                                 = "overGasLimitTxTC"
+	InsufficientBalanceTxTCName                          = "insufficientBalanceTxTC"
+	InvalidAccountKeyUpdateTCName                        = "invalidAccountKeyUpdateTC"
+	MalformedRLPTxTCName                                 = "malformedRLPTxTC"
+	FutureNonceTCName                                    = "futureNonceTC"
+	GasPriceReplacementTCName                            = "gasPriceReplacementTC"
+	CancelReplacementTCName                              = "cancelReplacementTC"
 )
 
 // ExtendedTask represents a test case
 type ExtendedTask struct {
-	Name          string
-	Weight        int
-	Init          func(accGrp *account.AccGroup, endpoint string, testContracts []account.TestContract, tcName string, targetTxTypeList []string) *TCConfig
-	Run           func(config *TCConfig) func()
-	TestContracts []account.TestContract // Required test contracts for this task
+	Name           string
+	Weight         int
+	Init           func(accGrp *account.AccGroup, endpoint string, testContracts []account.TestContract, tcName string, targetTxTypeList []string) *TCConfig
+	Run            func(config *TCConfig) func()
+	TestContracts  []account.TestContract // Required test contracts for this task
+	ReadOnly       bool                   // True if the task sends no tx, so it is not throttled by the txpool backpressure
+	NoBackpressure bool                   // True if the txs of the task never enter the txpool, so it is not throttled by the txpool backpressure either
+	Params         []TCParam              // Parameters which can be overridden by --tc-param
 }
 
 // TcList contains test cases
@@ -77,6 +126,7 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunNewValueTransferTC,
 		TestContracts: []account.TestContract{}, // No specific contract needed
+		Params:        []TCParam{maxValueParam},
 	},
 	NewValueTransferMemoTCName: {
 		Name:          NewValueTransferMemoTCName,
@@ -84,6 +134,7 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunNewValueTransferMemoTC,
 		TestContracts: []account.TestContract{}, // No specific contract needed
+		Params:        []TCParam{maxValueParam},
 	},
 	NewSmartContractExecutionTCName: {
 		Name:          NewSmartContractExecutionTCName,
@@ -98,6 +149,10 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunErc20TransferTC,
 		TestContracts: []account.TestContract{account.ContractErc20},
+		Params: []TCParam{
+			maxValueParam,
+			{Name: "charge", Default: 10000, Usage: "erc20 tokens charged to every test account at the setup"},
+		},
 	},
 	CpuHeavyTCName: {
 		Name:          CpuHeavyTCName,
@@ -105,6 +160,7 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunCpuHeavyTC,
 		TestContracts: []account.TestContract{account.ContractCPUHeavy},
+		Params:        []TCParam{{Name: "size", Default: 100, Usage: "value passed to the CPU heavy contract, which the computation grows with"}},
 	},
 	NewFeeDelegatedValueTransferTCName: {
 		Name:          NewFeeDelegatedValueTransferTCName,
@@ -112,6 +168,7 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunNewFeeDelegatedValueTransferTC,
 		TestContracts: []account.TestContract{},
+		Params:        []TCParam{maxValueParam},
 	},
 	NewFeeDelegatedValueTransferWithRatioTCName: {
 		Name:          NewFeeDelegatedValueTransferWithRatioTCName,
@@ -119,6 +176,10 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunNewFeeDelegatedValueTransferWithRatioTC,
 		TestContracts: []account.TestContract{},
+		Params: []TCParam{
+			maxValueParam,
+			feeRatioParam,
+		},
 	},
 	NewFeeDelegatedValueTransferMemoTCName: {
 		Name:          NewFeeDelegatedValueTransferMemoTCName,
@@ -126,6 +187,7 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunNewFeeDelegatedValueTransferMemoTC,
 		TestContracts: []account.TestContract{},
+		Params:        []TCParam{maxValueParam},
 	},
 	NewFeeDelegatedValueTransferMemoWithRatioTCName: {
 		Name:          NewFeeDelegatedValueTransferMemoWithRatioTCName,
@@ -133,6 +195,10 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunNewFeeDelegatedValueTransferMemoWithRatioTC,
 		TestContracts: []account.TestContract{},
+		Params: []TCParam{
+			maxValueParam,
+			feeRatioParam,
+		},
 	},
 	NewFeeDelegatedSmartContractDeployTCName: {
 		Name:          NewFeeDelegatedSmartContractDeployTCName,
@@ -140,6 +206,7 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunNewFeeDelegatedSmartContractDeployTC,
 		TestContracts: []account.TestContract{},
+		Params:        []TCParam{maxValueParam},
 	},
 	NewFeeDelegatedSmartContractDeployWithRatioTCName: {
 		Name:          NewFeeDelegatedSmartContractDeployWithRatioTCName,
@@ -147,6 +214,10 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunNewFeeDelegatedSmartContractDeployWithRatioTC,
 		TestContracts: []account.TestContract{},
+		Params: []TCParam{
+			maxValueParam,
+			feeRatioParam,
+		},
 	},
 	NewFeeDelegatedSmartContractExecutionTCName: {
 		Name:          NewFeeDelegatedSmartContractExecutionTCName,
@@ -161,6 +232,7 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunNewFeeDelegatedSmartContractExecutionWithRatioTC,
 		TestContracts: []account.TestContract{account.ContractGeneral},
+		Params:        []TCParam{feeRatioParam},
 	},
 	NewValueTransferWithCancelTCName: {
 		Name:          NewValueTransferWithCancelTCName,
@@ -168,6 +240,7 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunNewValueTransferWithCancelTC,
 		TestContracts: []account.TestContract{},
+		Params:        []TCParam{maxValueParam},
 	},
 	NewValueTransferLargeMemoTCName: {
 		Name:          NewValueTransferLargeMemoTCName,
@@ -175,6 +248,7 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunNewValueTransferLargeMemoTC,
 		TestContracts: []account.TestContract{},
+		Params:        []TCParam{maxValueParam},
 	},
 	NewValueTransferSmallMemoTCName: {
 		Name:          NewValueTransferSmallMemoTCName,
@@ -182,6 +256,7 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunNewValueTransferSmallMemoTC,
 		TestContracts: []account.TestContract{},
+		Params:        []TCParam{maxValueParam},
 	},
 	NewCancelTCName: {
 		Name:          NewCancelTCName,
@@ -189,6 +264,7 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunNewCancelTC,
 		TestContracts: []account.TestContract{},
+		Params:        []TCParam{maxValueParam},
 	},
 	NewFeeDelegatedCancelTCName: {
 		Name:          NewFeeDelegatedCancelTCName,
@@ -196,6 +272,7 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunNewFeeDelegatedCancelTC,
 		TestContracts: []account.TestContract{},
+		Params:        []TCParam{maxValueParam},
 	},
 	NewFeeDelegatedCancelWithRatioTCName: {
 		Name:          NewFeeDelegatedCancelWithRatioTCName,
@@ -203,6 +280,38 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunNewFeeDelegatedCancelWithRatioTC,
 		TestContracts: []account.TestContract{},
+		Params: []TCParam{
+			maxValueParam,
+			feeRatioParam,
+		},
+	},
+	NewChainDataAnchoringTCName: {
+		Name:          NewChainDataAnchoringTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunNewChainDataAnchoringTC,
+		TestContracts: []account.TestContract{},
+		Params:        []TCParam{anchoringFormatParam, anchoringDataSizeParam},
+	},
+	NewFeeDelegatedChainDataAnchoringTCName: {
+		Name:          NewFeeDelegatedChainDataAnchoringTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunNewFeeDelegatedChainDataAnchoringTC,
+		TestContracts: []account.TestContract{},
+		Params:        []TCParam{anchoringFormatParam, anchoringDataSizeParam},
+	},
+	NewFeeDelegatedChainDataAnchoringWithRatioTCName: {
+		Name:          NewFeeDelegatedChainDataAnchoringWithRatioTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunNewFeeDelegatedChainDataAnchoringWithRatioTC,
+		TestContracts: []account.TestContract{},
+		Params: []TCParam{
+			feeRatioParam,
+			anchoringFormatParam,
+			anchoringDataSizeParam,
+		},
 	},
 	NewSmartContractDeployTCName: {
 		Name:          NewSmartContractDeployTCName,
@@ -217,6 +326,34 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunLargeMemoTC,
 		TestContracts: []account.TestContract{account.ContractLargeMemo},
+		Params: []TCParam{
+			{Name: "minSize", Default: 50, Usage: "min size of the memo stored by every tx"},
+			{Name: "maxSize", Default: 2000, Usage: "max size of the memo stored by every tx"},
+		},
+	},
+	ComputationLimitTCName: {
+		Name:          ComputationLimitTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunComputationLimitTC,
+		TestContracts: []account.TestContract{account.ContractComputationLoop},
+		Params: []TCParam{
+			{Name: "metric", Default: LimitMetricComputation, Usage: "cost which every tx targets: computation (the Kaia computation cost) or gas"},
+			{Name: "target", Default: uint64(0), Usage: "target cost per tx, 0 is 90% of the per-tx limit of the metric. It may exceed the limit"},
+		},
+	},
+	LogHeavyTCName: {
+		Name:          LogHeavyTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunLogHeavyTC,
+		TestContracts: []account.TestContract{account.ContractLogHeavy},
+		Params: []TCParam{
+			{Name: "events", Default: 10, Usage: "events emitted by every tx"},
+			{Name: "indexed", Default: 3, Usage: "indexed arguments of every event, up to 3, or 4 with anonymous"},
+			{Name: "dataSize", Default: 256, Usage: "bytes of the non-indexed data of every event"},
+			{Name: "anonymous", Default: false, Usage: "emit anonymous events, without the signature topic"},
+		},
 	},
 	Erc721TransferTCName: {
 		Name:          Erc721TransferTCName,
@@ -224,6 +361,7 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunErc721TransferTC,
 		TestContracts: []account.TestContract{account.ContractErc721},
+		Params:        []TCParam{{Name: "mintPerAccount", Default: 5, Usage: "erc721 tokens minted to every test account at the setup"}},
 	},
 	AuctionBidTCName: {
 		Name:          AuctionBidTCName,
@@ -231,6 +369,11 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunAuctionBidTC,
 		TestContracts: []account.TestContract{account.ContractAuctionEntryPoint, account.ContractCounterForTestAuction},
+		Params: []TCParam{
+			{Name: "bid", Default: uint64(2), Usage: "bid amount in kei of every bid"},
+			{Name: "blocks", Default: 2, Usage: "number of the next blocks bid for the same target tx"},
+			{Name: "callGasLimit", Default: uint64(5000000), Usage: "call gas limit of every bid"},
+		},
 	},
 	AuctionRevertedBidTCName: {
 		Name:          AuctionRevertedBidTCName,
@@ -238,6 +381,27 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunAuctionRevertedBidTC,
 		TestContracts: []account.TestContract{account.ContractAuctionEntryPoint, account.ContractCounterForTestAuction},
+		Params: []TCParam{
+			{Name: "bid", Default: uint64(2), Usage: "bid amount in kei of every bid"},
+			{Name: "callGasLimit", Default: uint64(5000000), Usage: "call gas limit of every bid"},
+		},
+	},
+	AuctionCompetitiveBidTCName: {
+		Name:          AuctionCompetitiveBidTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunAuctionCompetitiveBidTC,
+		TestContracts: []account.TestContract{account.ContractAuctionEntryPoint, account.ContractCounterForTestAuction},
+		Params: []TCParam{
+			{Name: "searchers", Default: 3, Usage: "number of the searchers bidding on the same target tx"},
+			{Name: "minBid", Default: uint64(1), Usage: "min bid amount in kei"},
+			{Name: "maxBid", Default: uint64(10), Usage: "max bid amount in kei"},
+			{Name: "bidDistribution", Default: "uniform", Usage: "distribution of the bids in [minBid, maxBid]: uniform, equal (all maxBid) or spread (evenly spaced)"},
+			{Name: "blocks", Default: 1, Usage: "number of the next blocks bid for the target tx, up to 2"},
+			{Name: "bidDelayMs", Default: 0, Usage: "delay in ms of the bids after a new block"},
+			{Name: "bidJitterMs", Default: 0, Usage: "max random delay in ms of every searcher after bidDelayMs"},
+			{Name: "callGasLimit", Default: uint64(5000000), Usage: "call gas limit of every bid"},
+		},
 	},
 	GaslessTransactionTCName: {
 		Name:          GaslessTransactionTCName,
@@ -266,6 +430,8 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunGasPrice,
 		TestContracts: []account.TestContract{},
+		ReadOnly:      true,
+		Params:        []TCParam{readNamespaceParam},
 	},
 	ReadBlockNumberTCName: {
 		Name:          ReadBlockNumberTCName,
@@ -273,6 +439,8 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunBlockNumber,
 		TestContracts: []account.TestContract{},
+		ReadOnly:      true,
+		Params:        []TCParam{readNamespaceParam},
 	},
 	ReadGetBlockByNumberTCName: {
 		Name:          ReadGetBlockByNumberTCName,
@@ -280,6 +448,8 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunGetBlockByNumber,
 		TestContracts: []account.TestContract{},
+		ReadOnly:      true,
+		Params:        []TCParam{readNamespaceParam},
 	},
 	ReadGetAccountTCName: {
 		Name:          ReadGetAccountTCName,
@@ -287,6 +457,8 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunGetAccount,
 		TestContracts: []account.TestContract{},
+		ReadOnly:      true,
+		Params:        []TCParam{readNamespaceParam},
 	},
 	ReadGetBlockWithConsensusInfoByNumberTCName: {
 		Name:          ReadGetBlockWithConsensusInfoByNumberTCName,
@@ -294,6 +466,8 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunGetBlockWithConsensusInfoByNumber,
 		TestContracts: []account.TestContract{},
+		ReadOnly:      true,
+		Params:        []TCParam{readNamespaceParam},
 	},
 	ReadGetStorageAtTCName: {
 		Name:          ReadGetStorageAtTCName,
@@ -301,6 +475,8 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunGetStorageAt,
 		TestContracts: []account.TestContract{account.ContractReadApiCallContract},
+		ReadOnly:      true,
+		Params:        []TCParam{readNamespaceParam},
 	},
 	ReadCallTCName: {
 		Name:          ReadCallTCName,
@@ -308,6 +484,8 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunCall,
 		TestContracts: []account.TestContract{account.ContractReadApiCallContract},
+		ReadOnly:      true,
+		Params:        []TCParam{readNamespaceParam},
 	},
 	ReadEstimateGasTCName: {
 		Name:          ReadEstimateGasTCName,
@@ -315,6 +493,189 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunEstimateGas,
 		TestContracts: []account.TestContract{account.ContractReadApiCallContract},
+		ReadOnly:      true,
+		Params:        []TCParam{readNamespaceParam},
+	},
+	ReadHistoricalBalanceTCName: {
+		Name:          ReadHistoricalBalanceTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunHistoricalBalanceTC,
+		TestContracts: []account.TestContract{},
+		ReadOnly:      true,
+		Params:        historicalSnapshotParams,
+	},
+	ReadHistoricalNonceTCName: {
+		Name:          ReadHistoricalNonceTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunHistoricalNonceTC,
+		TestContracts: []account.TestContract{},
+		ReadOnly:      true,
+		Params:        historicalSnapshotParams,
+	},
+	ReadHistoricalCodeTCName: {
+		Name:          ReadHistoricalCodeTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunHistoricalCodeTC,
+		TestContracts: []account.TestContract{account.ContractReadApiCallContract},
+		ReadOnly:      true,
+		Params:        historicalBlockParams,
+	},
+	ReadHistoricalStorageAtTCName: {
+		Name:          ReadHistoricalStorageAtTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunHistoricalStorageAtTC,
+		TestContracts: []account.TestContract{account.ContractReadApiCallContract},
+		ReadOnly:      true,
+		Params:        historicalBlockParams,
+	},
+	ReadHistoricalCallTCName: {
+		Name:          ReadHistoricalCallTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunHistoricalCallTC,
+		TestContracts: []account.TestContract{account.ContractReadApiCallContract},
+		ReadOnly:      true,
+		Params:        historicalBlockParams,
+	},
+	ReadNamespaceDiffTCName: {
+		Name:          ReadNamespaceDiffTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunReadNamespaceDiffTC,
+		TestContracts: []account.TestContract{},
+		ReadOnly:      true,
+		Params: []TCParam{
+			{Name: "namespaces", Default: "kaia,eth", Usage: "two RPC namespaces whose blocks, txs and receipts are compared, separated by comma"},
+		},
+	},
+	DebugTraceTransactionTCName: {
+		Name:          DebugTraceTransactionTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunDebugTraceTransactionTC,
+		TestContracts: []account.TestContract{},
+		ReadOnly:      true,
+		Params:        debugTraceTargetParams,
+	},
+	DebugTraceBlockByNumberTCName: {
+		Name:          DebugTraceBlockByNumberTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunDebugTraceBlockByNumberTC,
+		TestContracts: []account.TestContract{},
+		ReadOnly:      true,
+		Params:        debugTraceTargetParams,
+	},
+	DebugTraceCallTCName: {
+		Name:          DebugTraceCallTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunDebugTraceCallTC,
+		TestContracts: []account.TestContract{account.ContractReadApiCallContract},
+		ReadOnly:      true,
+		Params:        debugTraceParams,
+	},
+	GetLogsTCName: {
+		Name:          GetLogsTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunGetLogsTC,
+		TestContracts: []account.TestContract{account.ContractErc20, account.ContractErc721, account.ContractInternalTxMain},
+		ReadOnly:      true,
+		Params: append([]TCParam{
+			{Name: "rangeWidths", Default: "1,10,100,1000", Usage: "block range widths, one of them is picked for every call, separated by comma"},
+		}, logQueryParams...),
+	},
+	FilterChangesTCName: {
+		Name:          FilterChangesTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunFilterChangesTC,
+		TestContracts: []account.TestContract{account.ContractErc20, account.ContractErc721, account.ContractInternalTxMain},
+		ReadOnly:      true,
+		Params: append([]TCParam{
+			{Name: "filterPoolSize", Default: 100, Usage: "max number of the installed and polled filters"},
+		}, logQueryParams...),
+	},
+	EIP7702SetCodeTCName: {
+		Name:          EIP7702SetCodeTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunEIP7702SetCodeTC,
+		TestContracts: []account.TestContract{account.ContractEIP7702Delegate, account.ContractGeneral},
+		Params:        []TCParam{eip7702PoolSizeParam},
+	},
+	EIP7702MultiAuthTCName: {
+		Name:          EIP7702MultiAuthTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunEIP7702MultiAuthTC,
+		TestContracts: []account.TestContract{account.ContractEIP7702Delegate, account.ContractGeneral},
+		Params: []TCParam{
+			eip7702PoolSizeParam,
+			{Name: "auths", Default: 4, Usage: "authorizations per tx, up to 50 and the pool size"},
+		},
+	},
+	EIP7702RedelegateTCName: {
+		Name:          EIP7702RedelegateTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunEIP7702RedelegateTC,
+		TestContracts: []account.TestContract{account.ContractEIP7702Delegate, account.ContractGeneral},
+		Params:        []TCParam{eip7702PoolSizeParam},
+	},
+	EIP7702RevokeTCName: {
+		Name:          EIP7702RevokeTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunEIP7702RevokeTC,
+		TestContracts: []account.TestContract{account.ContractEIP7702Delegate, account.ContractGeneral},
+		Params:        []TCParam{eip7702PoolSizeParam},
+	},
+	EIP7702DelegatedCallTCName: {
+		Name:          EIP7702DelegatedCallTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunEIP7702DelegatedCallTC,
+		TestContracts: []account.TestContract{account.ContractEIP7702Delegate, account.ContractGeneral},
+		Params:        []TCParam{eip7702PoolSizeParam},
+	},
+	UniqueDeployTCName: {
+		Name:          UniqueDeployTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunUniqueDeployTC,
+		TestContracts: []account.TestContract{},
+		Params:        deployParams,
+	},
+	Create2DeployTCName: {
+		Name:          Create2DeployTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunCreate2DeployTC,
+		TestContracts: []account.TestContract{account.ContractCreate2Factory},
+		Params:        deployParams,
+	},
+	StateGrowthAccountTCName: {
+		Name:          StateGrowthAccountTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunStateGrowthAccountTC,
+		TestContracts: []account.TestContract{},
+	},
+	StateGrowthStorageTCName: {
+		Name:          StateGrowthStorageTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunStateGrowthStorageTC,
+		TestContracts: []account.TestContract{account.ContractStateGrowth},
+		Params: []TCParam{
+			{Name: "slots", Default: 10, Usage: "new storage slots written by every tx, up to 200"},
+		},
 	},
 	InternalTxTCName: {
 		Name:          InternalTxTCName,
@@ -357,6 +718,7 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunNewAccountUpdateTC,
 		TestContracts: []account.TestContract{},
+		Params:        []TCParam{maxValueParam},
 	},
 	NewFeeDelegatedAccountUpdateTCName: {
 		Name:          NewFeeDelegatedAccountUpdateTCName,
@@ -364,6 +726,7 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunNewFeeDelegatedAccountUpdateTC,
 		TestContracts: []account.TestContract{},
+		Params:        []TCParam{maxValueParam},
 	},
 	NewFeeDelegatedAccountUpdateWithRatioTCName: {
 		Name:          NewFeeDelegatedAccountUpdateWithRatioTCName,
@@ -371,6 +734,10 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunNewFeeDelegatedAccountUpdateWithRatioTC,
 		TestContracts: []account.TestContract{},
+		Params: []TCParam{
+			maxValueParam,
+			feeRatioParam,
+		},
 	},
 	TransferSignedTCName: {
 		Name:          TransferSignedTCName,
@@ -378,6 +745,7 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunTransferSignedTC,
 		TestContracts: []account.TestContract{},
+		Params:        []TCParam{maxValueParam},
 	},
 	TransferUnsignedTCName: {
 		Name:          TransferUnsignedTCName,
@@ -385,6 +753,7 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunTransferUnsignedTC,
 		TestContracts: []account.TestContract{},
+		Params:        []TCParam{maxValueParam},
 	},
 	ReceiptCheckTCName: {
 		Name:          ReceiptCheckTCName,
@@ -392,6 +761,16 @@ var TcList = map[string]*ExtendedTask{
 		Init:          Init,
 		Run:           RunReceiptCheckTC,
 		TestContracts: []account.TestContract{},
+		Params: []TCParam{
+			{Name: "readPerSend", Default: 9, Usage: "reads per send, i.e. read:send = readPerSend:1"},
+			{Name: "poolSize", Default: 30000, Usage: "number of tx hashes kept for the reads"},
+			{Name: "warmUp", Default: 10000, Usage: "number of txs sent before the reads start, only with the own hash source"},
+			{Name: "hashSource", Default: HashSourceOwn, Usage: "source of the read tx hashes: own (sent by itself), blocks (recent and new blocks) or file"},
+			{Name: "hashFile", Default: "", Usage: "file of the tx hashes for the file hash source, one hex hash per line"},
+			{Name: "missingRatio", Default: 0.0, Usage: "share of the reads which query a non-existent hash"},
+			{Name: "txByHashRatio", Default: 0.0, Usage: "share of the reads which call getTransactionByHash instead of getTransactionReceipt"},
+			{Name: "scanBlocks", Default: 10000, Usage: "max number of recent blocks scanned to fill the hash pool with the blocks hash source"},
+		},
 	},
 	TransferSignedWithCheckTCName: {
 		Name:          TransferSignedWithCheckTCName,
@@ -435,4 +814,163 @@ var TcList = map[string]*ExtendedTask{
 		Run:           RunNewEthereumDynamicFeeTC,
 		TestContracts: []account.TestContract{account.ContractGeneral},
 	},
+	PublicKeyValueTransferTCName: {
+		Name:          PublicKeyValueTransferTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunNewValueTransferTC,
+		TestContracts: []account.TestContract{}, // No specific contract needed
+	},
+	PublicKeySmartContractExecutionTCName: {
+		Name:          PublicKeySmartContractExecutionTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunNewSmartContractExecutionTC,
+		TestContracts: []account.TestContract{account.ContractGeneral},
+	},
+	MultiSigValueTransferTCName: {
+		Name:          MultiSigValueTransferTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunNewValueTransferTC,
+		TestContracts: []account.TestContract{}, // No specific contract needed
+	},
+	MultiSigSmartContractExecutionTCName: {
+		Name:          MultiSigSmartContractExecutionTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunNewSmartContractExecutionTC,
+		TestContracts: []account.TestContract{account.ContractGeneral},
+	},
+	RoleBasedValueTransferTCName: {
+		Name:          RoleBasedValueTransferTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunNewValueTransferTC,
+		TestContracts: []account.TestContract{}, // No specific contract needed
+	},
+	RoleBasedSmartContractExecutionTCName: {
+		Name:          RoleBasedSmartContractExecutionTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunNewSmartContractExecutionTC,
+		TestContracts: []account.TestContract{account.ContractGeneral},
+	},
+	NewAccountCreationTCName: {
+		Name:          NewAccountCreationTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunNewAccountCreationTC,
+		TestContracts: []account.TestContract{}, // No specific contract needed
+	},
+	CreatedAccountValueTransferTCName: {
+		Name:          CreatedAccountValueTransferTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunCreatedAccountValueTransferTC,
+		TestContracts: []account.TestContract{}, // No specific contract needed
+	},
+	InvalidChainIDTCName: {
+		Name:           InvalidChainIDTCName,
+		Weight:         10,
+		Init:           Init,
+		Run:            RunInvalidChainIDTC,
+		TestContracts:  []account.TestContract{},
+		NoBackpressure: true, // The invalid txs never enter the txpool
+	},
+	InvalidSignatureTCName: {
+		Name:           InvalidSignatureTCName,
+		Weight:         10,
+		Init:           Init,
+		Run:            RunInvalidSignatureTC,
+		TestContracts:  []account.TestContract{},
+		NoBackpressure: true, // The invalid txs never enter the txpool
+	},
+	InvalidFeePayerSignatureTCName: {
+		Name:           InvalidFeePayerSignatureTCName,
+		Weight:         10,
+		Init:           Init,
+		Run:            RunInvalidFeePayerSignatureTC,
+		TestContracts:  []account.TestContract{},
+		NoBackpressure: true, // The invalid txs never enter the txpool
+	},
+	UnderpricedTxTCName: {
+		Name:           UnderpricedTxTCName,
+		Weight:         10,
+		Init:           Init,
+		Run:            RunUnderpricedTxTC,
+		TestContracts:  []account.TestContract{},
+		NoBackpressure: true, // The invalid txs never enter the txpool
+	},
+	OversizedTxTCName: {
+		Name:           OversizedTxTCName,
+		Weight:         10,
+		Init:           Init,
+		Run:            RunOversizedTxTC,
+		TestContracts:  []account.TestContract{},
+		NoBackpressure: true, // The invalid txs never enter the txpool
+	},
+	OverGasLimitTxTCName: {
+		Name:           OverGasLimitTxTCName,
+		Weight:         10,
+		Init:           Init,
+		Run:            RunOverGasLimitTxTC,
+		TestContracts:  []account.TestContract{},
+		NoBackpressure: true, // The invalid txs never enter the txpool
+	},
+	InsufficientBalanceTxTCName: {
+		Name:           InsufficientBalanceTxTCName,
+		Weight:         10,
+		Init:           Init,
+		Run:            RunInsufficientBalanceTxTC,
+		TestContracts:  []account.TestContract{},
+		NoBackpressure: true, // The invalid txs never enter the txpool
+	},
+	InvalidAccountKeyUpdateTCName: {
+		Name:           InvalidAccountKeyUpdateTCName,
+		Weight:         10,
+		Init:           Init,
+		Run:            RunInvalidAccountKeyUpdateTC,
+		TestContracts:  []account.TestContract{},
+		NoBackpressure: true, // The invalid txs never enter the txpool
+	},
+	MalformedRLPTxTCName: {
+		Name:           MalformedRLPTxTCName,
+		Weight:         10,
+		Init:           Init,
+		Run:            RunMalformedRLPTxTC,
+		TestContracts:  []account.TestContract{},
+		NoBackpressure: true, // The invalid txs never enter the txpool
+	},
+	FutureNonceTCName: {
+		Name:          FutureNonceTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunFutureNonceTC,
+		TestContracts: []account.TestContract{},
+		Params: []TCParam{
+			{Name: "minGap", Default: 1, Usage: "min nonce gap of the queued tx"},
+			{Name: "maxGap", Default: 5, Usage: "max nonce gap of the queued tx, less than 64"},
+			{Name: "fillDelayMs", Default: 1000, Usage: "delay in ms before filling the nonce gap, during which the account is locked"},
+		},
+	},
+	GasPriceReplacementTCName: {
+		Name:          GasPriceReplacementTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunGasPriceReplacementTC,
+		TestContracts: []account.TestContract{},
+		Params: []TCParam{
+			maxValueParam,
+			{Name: "priceBumpPercent", Default: 10, Usage: "gas price bump in percent of the replacement tx"},
+		},
+	},
+	CancelReplacementTCName: {
+		Name:          CancelReplacementTCName,
+		Weight:        10,
+		Init:          Init,
+		Run:           RunCancelReplacementTC,
+		TestContracts: []account.TestContract{},
+		Params:        []TCParam{maxValueParam},
+	},
 }
@@ -0,0 +1,208 @@
+package testcase
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kaiachain/kaia-load-tester/klayslave/account"
+	"github.com/kaiachain/kaia/blockchain/types"
+)
+
+// TCParam declares a parameter of a test case, which is overridden by `--tc-param <tc>.<name>=<value>` or the scenario file.
+// The type of Default decides the type of the parameter: int, uint64, float64, bool, time.Duration or string.
+// A list is a string of the values separated by comma.
+type TCParam struct {
+	Name    string
+	Default interface{}
+	Usage   string
+}
+
+// parse parses the value in the type of the default.
+func (p TCParam) parse(value string) (interface{}, error) {
+	switch p.Default.(type) {
+	case int:
+		return strconv.Atoi(value)
+	case uint64:
+		return strconv.ParseUint(value, 10, 64)
+	case float64:
+		return strconv.ParseFloat(value, 64)
+	case bool:
+		return strconv.ParseBool(value)
+	case time.Duration:
+		return time.ParseDuration(value)
+	case string:
+		return value, nil
+	default:
+		return nil, fmt.Errorf("unsupported parameter type %T", p.Default)
+	}
+}
+
+// maxValueParam is the parameter of the TCs which send a random value of [0, maxValue].
+var maxValueParam = TCParam{Name: "maxValue", Default: 2, Usage: "max value in kei sent by every tx, the value is picked from [0, maxValue]"}
+
+// feeRatioParam is the parameter of the fee delegated with ratio TCs.
+var feeRatioParam = TCParam{Name: "feeRatio", Default: 30, Usage: "fee ratio of the fee payer in percent, between 1 and 99"}
+
+// gasLimitParam is the parameter of every TC whose txs enter the txpool. It is added to them by init.
+var gasLimitParam = TCParam{Name: "gasLimit", Default: uint64(0), Usage: "gas limit of every tx, 0 keeps the built-in gas limit of each tx"}
+
+func init() {
+	for _, task := range TcList {
+		if !task.ReadOnly && !task.NoBackpressure {
+			// copied, as the parameters may be shared by the TCs
+			task.Params = append(append([]TCParam{}, task.Params...), gasLimitParam)
+		}
+	}
+}
+
+// tcParamValues are the values of the parameters of every TC, by the TC name and the parameter name.
+// They are the defaults unless overridden, and set by SetTCParams.
+var tcParamValues = map[string]map[string]interface{}{}
+
+// ValidateTCParam returns an error if the TC has no such parameter or the value is not of its type.
+func ValidateTCParam(tcName, name, value string) error {
+	task, ok := TcList[tcName]
+	if !ok {
+		return fmt.Errorf("unknown test case %v", tcName)
+	}
+	for _, p := range task.Params {
+		if p.Name == name {
+			if _, err := p.parse(value); err != nil {
+				return fmt.Errorf("invalid value of %v.%v, which should be %T: %v", tcName, name, p.Default, value)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("test case %v has no parameter %v", tcName, name)
+}
+
+// SetTCParams sets the parameters of every TC to the defaults, and then to the overrides given by the TC name and the parameter name.
+// The overrides should be validated by ValidateTCParam.
+func SetTCParams(overrides map[string]map[string]string) {
+	for tcName, task := range TcList {
+		values := make(map[string]interface{}, len(task.Params))
+		for _, p := range task.Params {
+			values[p.Name] = p.Default
+			if override, ok := overrides[tcName][p.Name]; ok {
+				value, err := p.parse(override)
+				if err != nil {
+					log.Fatalf("Invalid value of %v.%v: %v", tcName, p.Name, err)
+				}
+				values[p.Name] = value
+			}
+		}
+		tcParamValues[tcName] = values
+	}
+}
+
+func tcParamValue(tcName, name string) interface{} {
+	value, ok := tcParamValues[tcName][name]
+	if !ok {
+		log.Fatalf("Test case %v has no parameter %v", tcName, name)
+	}
+	return value
+}
+
+// IntParam returns the int parameter of the TC.
+func IntParam(tcName, name string) int { return tcParamValue(tcName, name).(int) }
+
+// Uint64Param returns the uint64 parameter of the TC.
+func Uint64Param(tcName, name string) uint64 { return tcParamValue(tcName, name).(uint64) }
+
+// StringParam returns the string parameter of the TC.
+func StringParam(tcName, name string) string { return tcParamValue(tcName, name).(string) }
+
+// Float64Param returns the float64 parameter of the TC.
+func Float64Param(tcName, name string) float64 { return tcParamValue(tcName, name).(float64) }
+
+// BoolParam returns the bool parameter of the TC.
+func BoolParam(tcName, name string) bool { return tcParamValue(tcName, name).(bool) }
+
+// DurationParam returns the time.Duration parameter of the TC.
+func DurationParam(tcName, name string) time.Duration {
+	return tcParamValue(tcName, name).(time.Duration)
+}
+
+// HasParam returns true if the TC declares the parameter.
+func (config *TCConfig) HasParam(name string) bool {
+	_, ok := tcParamValues[config.Name][name]
+	return ok
+}
+
+// IntParam returns the int parameter of the TC.
+func (config *TCConfig) IntParam(name string) int { return IntParam(config.Name, name) }
+
+// Uint64Param returns the uint64 parameter of the TC.
+func (config *TCConfig) Uint64Param(name string) uint64 { return Uint64Param(config.Name, name) }
+
+// StringParam returns the string parameter of the TC.
+func (config *TCConfig) StringParam(name string) string { return StringParam(config.Name, name) }
+
+// Float64Param returns the float64 parameter of the TC.
+func (config *TCConfig) Float64Param(name string) float64 { return Float64Param(config.Name, name) }
+
+// BoolParam returns the bool parameter of the TC.
+func (config *TCConfig) BoolParam(name string) bool { return BoolParam(config.Name, name) }
+
+// DurationParam returns the time.Duration parameter of the TC.
+func (config *TCConfig) DurationParam(name string) time.Duration {
+	return DurationParam(config.Name, name)
+}
+
+// ListParam returns the values of the list parameter of the TC, which are separated by comma.
+func (config *TCConfig) ListParam(name string) []string {
+	var values []string
+	for _, value := range strings.Split(config.StringParam(name), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// IntListParam returns the values of the list parameter of the TC as ints.
+func (config *TCConfig) IntListParam(name string) []int {
+	var values []int
+	for _, str := range config.ListParam(name) {
+		value, err := strconv.Atoi(str)
+		if err != nil {
+			log.Fatalf("%v.%v should be ints separated by comma: %v", config.Name, name, str)
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+// maxValue returns the maxValue parameter of the TC, or its default if the TC does not declare it.
+func (config *TCConfig) maxValue() int {
+	if !config.HasParam(maxValueParam.Name) {
+		return maxValueParam.Default.(int)
+	}
+	maxValue := config.IntParam(maxValueParam.Name)
+	if maxValue < 0 {
+		log.Fatalf("%v.%v should not be negative: %v", config.Name, maxValueParam.Name, maxValue)
+	}
+	return maxValue
+}
+
+// feeRatio returns the feeRatio parameter of the TC.
+func (config *TCConfig) feeRatio() types.FeeRatio {
+	ratio := config.IntParam(feeRatioParam.Name)
+	if ratio < 1 || ratio > 99 {
+		log.Fatalf("%v.%v should be between 1 and 99: %v", config.Name, feeRatioParam.Name, ratio)
+	}
+	return types.FeeRatio(ratio)
+}
+
+// setGasLimit sets the gasLimit parameter of the TC on a client of its pools, if it is not 0.
+func (config *TCConfig) setGasLimit(c interface{}) {
+	if !config.HasParam(gasLimitParam.Name) {
+		return
+	}
+	if gasLimit := config.Uint64Param(gasLimitParam.Name); gasLimit != 0 {
+		account.SetGasLimit(c, gasLimit)
+	}
+}
@@ -0,0 +1,247 @@
+package testcase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kaiachain/kaia-load-tester/klayslave/report"
+	"github.com/kaiachain/kaia/blockchain/types"
+	"github.com/kaiachain/kaia/common"
+	"github.com/kaiachain/kaia/common/hexutil"
+	"github.com/kaiachain/kaia/networks/rpc"
+	"github.com/myzhan/boomer"
+)
+
+// Receipt verification related variables
+var (
+	verifyRatio   = 0.0 // share of the sent txs whose receipts are verified, 0 disables the verifier
+	verifyQueue   chan verifyRequest
+	verifyTimeout = 60 * time.Second
+
+	verifyWorkers      = 64
+	verifyQueueSize    = 10000
+	verifyPollInterval = 500 * time.Millisecond
+
+	verifyStatsMu sync.Mutex
+	verifyStats   = make(map[string]*verifyStat)
+
+	// expectedTxTypes is the tx type of the TCs whose tx funcs return only a hash.
+	// The TCs which return a *types.Transaction are checked against the type of the tx itself.
+	expectedTxTypes = map[string]types.TxType{
+		NewValueTransferTCName:                               types.TxTypeValueTransfer,
+		NewValueTransferMemoTCName:                           types.TxTypeValueTransferMemo,
+		NewValueTransferLargeMemoTCName:                      types.TxTypeValueTransferMemo,
+		NewValueTransferSmallMemoTCName:                      types.TxTypeValueTransferMemo,
+		NewFeeDelegatedValueTransferTCName:                   types.TxTypeFeeDelegatedValueTransfer,
+		NewFeeDelegatedValueTransferWithRatioTCName:          types.TxTypeFeeDelegatedValueTransferWithRatio,
+		NewFeeDelegatedValueTransferMemoTCName:               types.TxTypeFeeDelegatedValueTransferMemo,
+		NewFeeDelegatedValueTransferMemoWithRatioTCName:      types.TxTypeFeeDelegatedValueTransferMemoWithRatio,
+		NewFeeDelegatedSmartContractDeployTCName:             types.TxTypeFeeDelegatedSmartContractDeploy,
+		NewFeeDelegatedSmartContractDeployWithRatioTCName:    types.TxTypeFeeDelegatedSmartContractDeployWithRatio,
+		NewFeeDelegatedSmartContractExecutionTCName:          types.TxTypeFeeDelegatedSmartContractExecution,
+		NewFeeDelegatedSmartContractExecutionWithRatioTCName: types.TxTypeFeeDelegatedSmartContractExecutionWithRatio,
+		NewCancelTCName:                                      types.TxTypeCancel,
+		NewValueTransferWithCancelTCName:                     types.TxTypeCancel,
+		NewFeeDelegatedCancelTCName:                          types.TxTypeFeeDelegatedCancel,
+		NewFeeDelegatedCancelWithRatioTCName:                 types.TxTypeFeeDelegatedCancelWithRatio,
+		NewChainDataAnchoringTCName:                          types.TxTypeChainDataAnchoring,
+		NewFeeDelegatedChainDataAnchoringTCName:              types.TxTypeFeeDelegatedChainDataAnchoring,
+		NewFeeDelegatedChainDataAnchoringWithRatioTCName:     types.TxTypeFeeDelegatedChainDataAnchoringWithRatio,
+		NewAccountUpdateTCName:                               types.TxTypeAccountUpdate,
+		NewFeeDelegatedAccountUpdateTCName:                   types.TxTypeFeeDelegatedAccountUpdate,
+		NewFeeDelegatedAccountUpdateWithRatioTCName:          types.TxTypeFeeDelegatedAccountUpdateWithRatio,
+		EIP7702SetCodeTCName:                                 types.TxTypeEthereumSetCode,
+		EIP7702MultiAuthTCName:                               types.TxTypeEthereumSetCode,
+		EIP7702RedelegateTCName:                              types.TxTypeEthereumSetCode,
+		EIP7702RevokeTCName:                                  types.TxTypeEthereumSetCode,
+		StateGrowthAccountTCName:                             types.TxTypeValueTransfer,
+		TransferSignedTCName:                                 types.TxTypeLegacyTransaction,
+		PublicKeyValueTransferTCName:                         types.TxTypeValueTransfer,
+		MultiSigValueTransferTCName:                          types.TxTypeValueTransfer,
+		RoleBasedValueTransferTCName:                         types.TxTypeValueTransfer,
+		CreatedAccountValueTransferTCName:                    types.TxTypeValueTransfer,
+	}
+)
+
+// deployedTx is returned by the tx funcs of the deploy TCs, so that the verifier also checks the contract address.
+type deployedTx struct {
+	tx              *types.Transaction
+	contractAddress common.Address
+}
+
+type verifyRequest struct {
+	tcName          string
+	hash            common.Hash
+	txType          *types.TxType   // nil if the type is not known
+	contractAddress *common.Address // nil if the tx is not a deploy with a known address
+	sentAt          time.Time
+}
+
+type verifyStat struct {
+	verified uint64
+	reverted uint64
+	failed   uint64 // not mined in time, or mined with an unexpected type or effect
+	dropped  uint64 // not verified because the queue was full
+}
+
+// rpcReceipt is the subset of the receipt fields returned by kaia_getTransactionReceipt which the verifier uses.
+type rpcReceipt struct {
+	Status          hexutil.Uint    `json:"status"`
+	TxError         *hexutil.Uint   `json:"txError"`
+	TypeInt         types.TxType    `json:"typeInt"`
+	ContractAddress *common.Address `json:"contractAddress"`
+}
+
+// StartReceiptVerifier starts the background workers which fetch the receipts of the given share of the sent txs.
+// The result of every tx is published as "<tc> verified" or "<tc> reverted" with the request type report.VerifyRequestType.
+func StartReceiptVerifier(endpoint string, ratio float64) error {
+	if ratio <= 0 {
+		return nil
+	}
+	verifyRatio = ratio
+	verifyQueue = make(chan verifyRequest, verifyQueueSize)
+	for i := 0; i < verifyWorkers; i++ {
+		cli, err := rpc.Dial(endpoint)
+		if err != nil {
+			return err
+		}
+		go verifyLoop(cli)
+	}
+	go reportVerifyStats()
+	return nil
+}
+
+// verifySampled queues the tx returned by a tx func for the verification if it is sampled.
+func verifySampled(tcName string, sent interface{}) {
+	if verifyRatio <= 0 || rand.Float64() >= verifyRatio {
+		return
+	}
+
+	hash, txType, contractAddress := describeSentTx(sent)
+	if hash == (common.Hash{}) {
+		return
+	}
+	req := verifyRequest{tcName: tcName, hash: hash, txType: txType, contractAddress: contractAddress, sentAt: time.Now()}
+	if req.txType == nil {
+		if txType, ok := expectedTxTypes[tcName]; ok {
+			req.txType = &txType
+		}
+	}
+
+	select {
+	case verifyQueue <- req:
+	default:
+		getVerifyStat(tcName, func(stat *verifyStat) { stat.dropped++ })
+	}
+}
+
+// describeSentTx returns the hash of what a tx func returned: a common.Hash, a *types.Transaction or a deployedTx.
+// The tx type and the contract address are also returned if known. The hash is empty for anything else.
+func describeSentTx(sent interface{}) (common.Hash, *types.TxType, *common.Address) {
+	switch v := sent.(type) {
+	case common.Hash:
+		return v, nil, nil
+	case *types.Transaction:
+		if v == nil {
+			return common.Hash{}, nil, nil
+		}
+		txType := v.Type()
+		return v.Hash(), &txType, nil
+	case deployedTx:
+		txType := v.tx.Type()
+		return v.tx.Hash(), &txType, &v.contractAddress
+	}
+	return common.Hash{}, nil, nil
+}
+
+func verifyLoop(cli *rpc.Client) {
+	for req := range verifyQueue {
+		reverted, err := verifyReceipt(cli, req)
+		elapsed := time.Since(req.sentAt).Milliseconds()
+		switch {
+		case reverted:
+			getVerifyStat(req.tcName, func(stat *verifyStat) { stat.reverted++ })
+			boomer.Events.Publish("request_failure", report.VerifyRequestType, req.tcName+" reverted", elapsed, err.Error())
+		case err != nil:
+			getVerifyStat(req.tcName, func(stat *verifyStat) { stat.failed++ })
+			boomer.Events.Publish("request_failure", report.VerifyRequestType, req.tcName+" verified", elapsed, err.Error())
+		default:
+			getVerifyStat(req.tcName, func(stat *verifyStat) { stat.verified++ })
+			boomer.Events.Publish("request_success", report.VerifyRequestType, req.tcName+" verified", elapsed, int64(10))
+		}
+	}
+}
+
+// verifyReceipt waits for the receipt of the tx and checks its status, type and contract address.
+// It returns true if the tx is mined but failed.
+func verifyReceipt(cli *rpc.Client, req verifyRequest) (bool, error) {
+	var receipt *rpcReceipt
+	for {
+		if err := cli.CallContext(context.Background(), &receipt, "kaia_getTransactionReceipt", req.hash); err == nil && receipt != nil {
+			break
+		}
+		if time.Since(req.sentAt) > verifyTimeout {
+			return false, fmt.Errorf("tx is not mined in %v", verifyTimeout)
+		}
+		time.Sleep(verifyPollInterval)
+	}
+
+	if receipt.Status != hexutil.Uint(types.ReceiptStatusSuccessful) {
+		txError := uint(0)
+		if receipt.TxError != nil {
+			txError = uint(*receipt.TxError)
+		}
+		return true, fmt.Errorf("tx reverted with txError %#x", txError)
+	}
+	if req.txType != nil && receipt.TypeInt != *req.txType {
+		return false, fmt.Errorf("unexpected tx type %v, expected %v", receipt.TypeInt, *req.txType)
+	}
+	if receipt.TypeInt.IsContractDeploy() || req.contractAddress != nil {
+		if receipt.ContractAddress == nil {
+			return false, fmt.Errorf("no contract address in the receipt of a deploy tx")
+		}
+		if req.contractAddress != nil && *receipt.ContractAddress != *req.contractAddress {
+			return false, fmt.Errorf("unexpected contract address %v, expected %v", receipt.ContractAddress.String(), req.contractAddress.String())
+		}
+	}
+	return false, nil
+}
+
+func getVerifyStat(tcName string, update func(stat *verifyStat)) {
+	verifyStatsMu.Lock()
+	defer verifyStatsMu.Unlock()
+	stat, ok := verifyStats[tcName]
+	if !ok {
+		stat = &verifyStat{}
+		verifyStats[tcName] = stat
+	}
+	update(stat)
+}
+
+// reportVerifyStats periodically logs the share of the verified txs which did real work and which reverted.
+func reportVerifyStats() {
+	for range time.Tick(10 * time.Second) {
+		verifyStatsMu.Lock()
+		var lines []string
+		for tcName, stat := range verifyStats {
+			total := stat.verified + stat.reverted + stat.failed
+			if total == 0 {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("%s: verified %d (%.1f%%), reverted %d (%.1f%%), failed %d, dropped %d",
+				tcName, stat.verified, float64(stat.verified)*100/float64(total), stat.reverted, float64(stat.reverted)*100/float64(total), stat.failed, stat.dropped))
+		}
+		verifyStatsMu.Unlock()
+
+		sort.Strings(lines)
+		if len(lines) > 0 {
+			log.Printf("Receipt verifier (ratio %v):\n  %s", verifyRatio, strings.Join(lines, "\n  "))
+		}
+	}
+}